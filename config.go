@@ -1,10 +1,16 @@
 package main
 
 import (
+	"crypto"
+	"crypto/rsa"
 	"fmt"
+	"net/http"
 	"os"
 	"path"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"git-release/release"
 
@@ -12,19 +18,76 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
 )
 
 // Configuration is a git-release settings struct
 type Configuration struct {
+	// release body
 	AllowEmptyChangelog bool
 	IgnoreChangelog     bool
-	UnreleasedCreate    bool
-	UnreleasedDelete    bool
-	TagPrefix           string
-	ReleaseName         string
-	ReleaseNamePrefix   string
-	ReleaseNameSuffix   string
+	CumulativeChangelog bool
 	ChangelogFile       string
+	ConventionalCommits bool
+	GenerateNotes       string
+	BodySource          string
+
+	// release and tag naming
+	TagPrefix         string
+	VersionScheme     release.VersionScheme
+	Components        []release.Component
+	ReleaseName       string
+	ReleaseNamePrefix string
+	ReleaseNameSuffix string
+	MakeLatest        string
+
+	// version tag creation and changelog preparation
+	Version          string
+	VersionTagPrefix string
+	AnnotatedTag     bool
+	PrepareTag       bool
+
+	// unreleased and nightly releases
+	UnreleasedCreate  bool
+	UnreleasedDelete  bool
+	UnreleasedRolling bool
+	UnreleasedNightly bool
+	KeepNightlies     int
+	NightlyMaxAge     int
+	SkipUnchanged     string
+
+	// existing releases
+	UpdateExisting  bool
+	ReleaseEvent    bool
+	FillReleaseBody bool
+
+	// assets
+	VerifyAssets      bool
+	ReuploadCorrupted bool
+	SBOMFormat        string
+	Provenance        bool
+	ProvenanceKey     string
+
+	// release gates
+	Preflight        bool
+	RequireSignedTag bool
+	TrustedKeys      string
+	Policies         *release.Policies
+
+	// prune command
+	PruneRules  *release.PruneRules
+	PruneTags   bool
+	PruneDryRun bool
+
+	// GitHub App authentication
+	AppID             string
+	AppPrivateKey     string
+	AppInstallationID int64
+
+	// http client
+	CABundle   string
+	ClientCert string
+	ClientKey  string
 }
 
 // GetConfig sets validated Release/Changelog configuration and returns github.com Token
@@ -35,15 +98,121 @@ func GetConfig(fs afero.Fs) (*Configuration, error) {
 		conf.AllowEmptyChangelog = true
 	}
 
+	if strings.ToLower(os.Getenv("CUMULATIVE_CHANGELOG")) == "true" {
+		conf.CumulativeChangelog = true
+	}
+
+	if strings.ToLower(os.Getenv("CONVENTIONAL_COMMITS")) == "true" {
+		conf.ConventionalCommits = true
+	}
+
+	if strings.ToLower(os.Getenv("UPDATE_EXISTING")) == "true" {
+		conf.UpdateExisting = true
+	}
+
+	switch strings.ToLower(os.Getenv("VERIFY_ASSETS")) {
+	case "true":
+		conf.VerifyAssets = true
+	case "reupload":
+		conf.VerifyAssets = true
+		conf.ReuploadCorrupted = true
+	case "", "false":
+		// do nothing
+	default:
+		return nil, errors.New("VERIFY_ASSETS not supported, possible values are [true, false, reupload]")
+	}
+
 	switch os.Getenv("UNRELEASED") {
 	case "update":
 		conf.UnreleasedCreate = true
 	case "delete":
 		conf.UnreleasedDelete = true
+	case "rolling":
+		conf.UnreleasedRolling = true
+	case "nightly":
+		conf.UnreleasedNightly = true
 	case "":
 		// do nothing
 	default:
-		return nil, errors.New("UNRELEASED not supported, possible values are [update, delete]")
+		return nil, errors.New("UNRELEASED not supported, possible values are [update, delete, rolling, nightly]")
+	}
+
+	switch strings.ToLower(os.Getenv("SKIP_UNCHANGED")) {
+	case release.SkipUnchangedCommit, release.SkipUnchangedContent:
+		conf.SkipUnchanged = strings.ToLower(os.Getenv("SKIP_UNCHANGED"))
+	case "":
+		// do nothing
+	default:
+		return nil, errors.New("SKIP_UNCHANGED not supported, possible values are [commit, content]")
+	}
+
+	var err error
+	if v := os.Getenv("KEEP_NIGHTLIES"); v != "" {
+		conf.KeepNightlies, err = strconv.Atoi(v)
+		if err != nil || conf.KeepNightlies < 0 {
+			return nil, errors.New("KEEP_NIGHTLIES should be a number of nightly releases")
+		}
+	}
+
+	if v := os.Getenv("NIGHTLY_MAX_AGE"); v != "" {
+		conf.NightlyMaxAge, err = strconv.Atoi(v)
+		if err != nil || conf.NightlyMaxAge < 0 {
+			return nil, errors.New("NIGHTLY_MAX_AGE should be a number of days")
+		}
+	}
+
+	rules := &release.PruneRules{Line: release.PruneLineMinor}
+	switch strings.ToLower(os.Getenv("PRUNE_LINE")) {
+	case release.PruneLineMajor, release.PruneLineMinor:
+		rules.Line = strings.ToLower(os.Getenv("PRUNE_LINE"))
+	case "":
+		// do nothing
+	default:
+		return nil, errors.New("PRUNE_LINE not supported, possible values are [major, minor]")
+	}
+
+	if v := os.Getenv("PRUNE_KEEP_PER_LINE"); v != "" {
+		rules.KeepPerLine, err = strconv.Atoi(v)
+		if err != nil || rules.KeepPerLine < 0 {
+			return nil, errors.New("PRUNE_KEEP_PER_LINE should be a number of releases")
+		}
+	}
+
+	if v := os.Getenv("PRUNE_PRERELEASE_AGE"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return nil, errors.New("PRUNE_PRERELEASE_AGE should be a number of days")
+		}
+		rules.PreReleaseAge = time.Duration(days) * 24 * time.Hour
+	}
+
+	if v := os.Getenv("PRUNE_DRAFT_AGE"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return nil, errors.New("PRUNE_DRAFT_AGE should be a number of days")
+		}
+		rules.DraftAge = time.Duration(days) * 24 * time.Hour
+	}
+
+	if rules.KeepPerLine != 0 || rules.PreReleaseAge != 0 || rules.DraftAge != 0 {
+		conf.PruneRules = rules
+	}
+
+	if strings.ToLower(os.Getenv("PRUNE_TAGS")) == "true" {
+		conf.PruneTags = true
+	}
+
+	if strings.ToLower(os.Getenv("PRUNE_DRY_RUN")) == "true" {
+		conf.PruneDryRun = true
+	}
+
+	conf.VersionScheme, err = release.NewVersionScheme(
+		strings.ToLower(os.Getenv("VERSION_SCHEME")),
+		os.Getenv("CALVER_FORMAT"),
+		os.Getenv("VERSION_REGEX"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error validating VERSION_SCHEME")
 	}
 
 	conf.TagPrefix = os.Getenv("TAG_PREFIX_REGEX")
@@ -55,24 +224,187 @@ func GetConfig(fs afero.Fs) (*Configuration, error) {
 		return nil, errors.New("both RELEASE_NAME and RELEASE_NAME_PREFIX / RELEASE_NAME_SUFFIX are set (expected RELEASE_NAME or combination/one of RELEASE_NAME_PREFIX and RELEASE_NAME_SUFFIX)")
 	}
 
+	switch strings.ToLower(os.Getenv("SBOM_FORMAT")) {
+	case release.SBOMFormatCycloneDX:
+		conf.SBOMFormat = release.SBOMFormatCycloneDX
+	case release.SBOMFormatSPDX:
+		conf.SBOMFormat = release.SBOMFormatSPDX
+	case "":
+		// do nothing
+	default:
+		return nil, errors.New("SBOM_FORMAT not supported, possible values are [cyclonedx, spdx]")
+	}
+
+	switch strings.ToLower(os.Getenv("GENERATE_NOTES")) {
+	case release.GenerateNotesFallback, release.GenerateNotesAppend, release.GenerateNotesPrepend, release.GenerateNotesOnly:
+		conf.GenerateNotes = strings.ToLower(os.Getenv("GENERATE_NOTES"))
+	case "":
+		// do nothing
+	default:
+		return nil, errors.New("GENERATE_NOTES not supported, possible values are [fallback, append, prepend, only]")
+	}
+
+	switch strings.ToLower(os.Getenv("BODY_SOURCE")) {
+	case release.BodySourceChangelog, "":
+		conf.BodySource = release.BodySourceChangelog
+	case release.BodySourceTag, release.BodySourceBoth:
+		conf.BodySource = strings.ToLower(os.Getenv("BODY_SOURCE"))
+	default:
+		return nil, errors.New("BODY_SOURCE not supported, possible values are [changelog, tag, both]")
+	}
+
+	switch strings.ToLower(os.Getenv("MAKE_LATEST")) {
+	case release.MakeLatestTrue, release.MakeLatestFalse, release.MakeLatestLegacy, release.MakeLatestAuto:
+		conf.MakeLatest = strings.ToLower(os.Getenv("MAKE_LATEST"))
+	case "":
+		// do nothing
+	default:
+		return nil, errors.New("MAKE_LATEST not supported, possible values are [true, false, legacy, auto]")
+	}
+
+	if strings.ToLower(os.Getenv("PROVENANCE")) == "true" {
+		conf.Provenance = true
+	}
+
+	conf.ProvenanceKey = os.Getenv("PROVENANCE_SIGNING_KEY")
+	if conf.ProvenanceKey != "" && !conf.Provenance {
+		return nil, errors.New("PROVENANCE_SIGNING_KEY is set while PROVENANCE is disabled")
+	}
+
+	if strings.ToLower(os.Getenv("REQUIRE_SIGNED_TAG")) == "true" {
+		conf.RequireSignedTag = true
+	}
+
+	conf.TrustedKeys = os.Getenv("TRUSTED_SIGNING_KEYS")
+	if conf.RequireSignedTag {
+		if conf.TrustedKeys == "" {
+			return nil, errors.New("REQUIRE_SIGNED_TAG is enabled while TRUSTED_SIGNING_KEYS is not set")
+		}
+
+		if conf.Unreleased() {
+			return nil, errors.New("REQUIRE_SIGNED_TAG can not be combined with UNRELEASED")
+		}
+	}
+
+	if os.Getenv("POLICIES") != "" {
+		conf.Policies = new(release.Policies)
+		for _, p := range strings.Split(strings.ToLower(os.Getenv("POLICIES")), ",") {
+			switch strings.TrimSpace(p) {
+			case release.PolicyLatestVersion:
+				conf.Policies.LatestVersion = true
+			case release.PolicyDefaultBranch:
+				conf.Policies.DefaultBranch = true
+			case release.PolicyChangelogDate:
+				conf.Policies.ChangelogDate = true
+			default:
+				return nil, errors.New(fmt.Sprintf("POLICIES not supported, possible values are [%v, %v, %v]", release.PolicyLatestVersion, release.PolicyDefaultBranch, release.PolicyChangelogDate))
+			}
+		}
+
+		conf.Policies.ChangelogMaxAge = 7
+		if v := os.Getenv("CHANGELOG_MAX_AGE"); v != "" {
+			conf.Policies.ChangelogMaxAge, err = strconv.Atoi(v)
+			if err != nil || conf.Policies.ChangelogMaxAge < 0 {
+				return nil, errors.New("CHANGELOG_MAX_AGE should be a number of days")
+			}
+		}
+
+		// version date is read from a changelog file only when it makes a release body
+		if conf.Policies.ChangelogDate && (conf.BodySource == release.BodySourceTag || conf.GenerateNotes == release.GenerateNotesOnly || conf.ConventionalCommits) {
+			return nil, errors.New(fmt.Sprintf("POLICIES %v can not be combined with BODY_SOURCE=tag, GENERATE_NOTES=only or CONVENTIONAL_COMMITS", release.PolicyChangelogDate))
+		}
+	}
+
+	if strings.ToLower(os.Getenv("PREPARE_TAG")) == "true" {
+		conf.PrepareTag = true
+	}
+
+	conf.AppID = os.Getenv("APP_ID")
+	conf.AppPrivateKey = os.Getenv("APP_PRIVATE_KEY")
+	if conf.AppID != "" && conf.AppPrivateKey == "" {
+		return nil, errors.New("APP_ID is set while APP_PRIVATE_KEY is not set")
+	} else if conf.AppID == "" && conf.AppPrivateKey != "" {
+		return nil, errors.New("APP_PRIVATE_KEY is set while APP_ID is not set")
+	}
+
+	if v := os.Getenv("APP_INSTALLATION_ID"); v != "" {
+		conf.AppInstallationID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || conf.AppInstallationID <= 0 {
+			return nil, errors.New("APP_INSTALLATION_ID should be a numeric installation id")
+		}
+	}
+
+	conf.Preflight = strings.ToLower(os.Getenv("PREFLIGHT")) != "false"
+
+	conf.CABundle = os.Getenv("CA_BUNDLE")
+	conf.ClientCert = os.Getenv("CLIENT_CERT")
+	conf.ClientKey = os.Getenv("CLIENT_KEY")
+	if (conf.ClientCert == "") != (conf.ClientKey == "") {
+		return nil, errors.New("CLIENT_CERT and CLIENT_KEY should be set together")
+	}
+
+	conf.Version = os.Getenv("VERSION")
+	conf.VersionTagPrefix = "v"
+	if _, ok := os.LookupEnv("VERSION_TAG_PREFIX"); ok {
+		conf.VersionTagPrefix = os.Getenv("VERSION_TAG_PREFIX")
+	}
+
+	if strings.ToLower(os.Getenv("ANNOTATED_TAG")) == "true" {
+		conf.AnnotatedTag = true
+	}
+
+	if os.Getenv("GITHUB_EVENT_NAME") == release.EventRelease {
+		if conf.Unreleased() {
+			return nil, errors.New("UNRELEASED can not be used in a workflow triggered by a release event")
+		}
+
+		conf.ReleaseEvent = true
+	}
+
+	if strings.ToLower(os.Getenv("FILL_RELEASE_BODY")) == "true" {
+		conf.FillReleaseBody = true
+	}
+
+	if conf.Version != "" {
+		if conf.Unreleased() {
+			return nil, errors.New("VERSION can not be combined with UNRELEASED")
+		}
+
+		if conf.RequireSignedTag {
+			return nil, errors.New("VERSION can not be combined with REQUIRE_SIGNED_TAG (created tags are not signed)")
+		}
+	}
+
 	c := os.Getenv("CHANGELOG_FILE")
 	if c == "" {
 		c = "CHANGELOG.md"
 	}
-	conf.ChangelogFile = path.Join(os.Getenv("GITHUB_WORKSPACE"), c)
 
-	b, err := afero.Exists(fs, conf.ChangelogFile)
-	if err != nil {
-		return nil, errors.Wrap(err, "error validating changelog file")
+	if err := conf.SetChangelogFile(fs, c); err != nil {
+		return nil, err
 	}
 
-	if !b {
-		if c != "none" {
-			log.Errorf("changelog file %v not found!", c)
+	if f := os.Getenv("COMPONENTS_FILE"); f != "" {
+		b, err := afero.ReadFile(fs, path.Join(os.Getenv("GITHUB_WORKSPACE"), f))
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading components file")
+		}
+
+		components := new(struct {
+			Components []release.Component `yaml:"components"`
+		})
+		if err := yaml.Unmarshal(b, components); err != nil {
+			return nil, errors.Wrap(err, "error parsing components file")
 		}
 
-		conf.ChangelogFile = ""
-		conf.IgnoreChangelog = true
+		if len(components.Components) == 0 {
+			return nil, errors.New("components file does not contain components")
+		}
+		conf.Components = components.Components
+
+		if conf.TagPrefix == "" {
+			conf.TagPrefix = release.ComponentsPrefixRegex(conf.Components)
+		}
 	}
 
 	// NOTE: deprecation warnings
@@ -89,6 +421,155 @@ func GetConfig(fs afero.Fs) (*Configuration, error) {
 	return conf, nil
 }
 
+// Unreleased reports whether an Unreleased release is published, in any of UNRELEASED modes
+func (c *Configuration) Unreleased() bool {
+	return c.UnreleasedCreate || c.UnreleasedDelete || c.UnreleasedRolling || c.UnreleasedNightly
+}
+
+// SetChangelogFile sets a changelog file path relative to the workspace, changelog is ignored when the file does not exist
+func (c *Configuration) SetChangelogFile(fs afero.Fs, file string) error {
+	c.ChangelogFile = path.Join(os.Getenv("GITHUB_WORKSPACE"), file)
+	c.IgnoreChangelog = false
+
+	b, err := afero.Exists(fs, c.ChangelogFile)
+	if err != nil {
+		return errors.Wrap(err, "error validating changelog file")
+	}
+
+	if !b {
+		if file != "none" {
+			log.Errorf("changelog file %v not found!", file)
+		}
+
+		c.ChangelogFile = ""
+		c.IgnoreChangelog = true
+	}
+
+	return nil
+}
+
+// GetVersionTag returns a tag to be created when a workflow is not triggered by a tag,
+// VERSION is either a tag or 'changelog' for the top version of a changelog file
+func (c *Configuration) GetVersionTag(fs afero.Fs) (string, error) {
+	if c.Version == "" || strings.HasPrefix(os.Getenv("GITHUB_REF"), "refs/tags/") {
+		return "", nil
+	}
+
+	if c.Version != release.VersionChangelog {
+		return c.Version, nil
+	}
+
+	if c.ChangelogFile == "" {
+		return "", errors.New("VERSION is set to 'changelog' while changelog file not found")
+	}
+
+	p, err := changelog.NewParserWithFilesystem(fs, c.ChangelogFile)
+	if err != nil {
+		return "", errors.Wrap(err, "error loading changelog file")
+	}
+
+	changes, err := p.Parse()
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing changelog file")
+	}
+
+	if len(changes.Releases) == 0 {
+		return "", errors.New("changelog file does not contain versions")
+	}
+
+	sort.Sort(sort.Reverse(changes.Releases))
+	return fmt.Sprintf("%v%v", c.VersionTagPrefix, *changes.Releases[0].Version), nil
+}
+
+// GetSigningKey loads a provenance signing key either from a PEM encoded value or from a file
+func (c *Configuration) GetSigningKey(fs afero.Fs) (crypto.Signer, error) {
+	if c.ProvenanceKey == "" {
+		return nil, nil
+	}
+
+	b, err := loadPEM(fs, c.ProvenanceKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading signing key file")
+	}
+
+	return release.ParseSigningKey(b)
+}
+
+// GetAppKey loads a GitHub App private key either from a value or from a file
+func (c *Configuration) GetAppKey(fs afero.Fs) (*rsa.PrivateKey, error) {
+	b, err := loadPEM(fs, c.AppPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading private key file")
+	}
+
+	return release.ParseAppKey(b)
+}
+
+// GetHTTPClient returns an HTTP client trusting CA_BUNDLE and presenting CLIENT_CERT, each loaded either from a value or from a file
+func (c *Configuration) GetHTTPClient(fs afero.Fs) (*http.Client, error) {
+	ca, err := loadPEM(fs, c.CABundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading CA bundle file")
+	}
+
+	cert, err := loadPEM(fs, c.ClientCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading client certificate file")
+	}
+
+	key, err := loadPEM(fs, c.ClientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading client key file")
+	}
+
+	return release.NewHTTPClient(ca, cert, key)
+}
+
+// loadPEM returns a PEM encoded 'value' as is, or a content of a file it points to.
+// Relative paths are resolved against the workspace, absolute ones (e.g. under RUNNER_TEMP) are used as is.
+func loadPEM(fs afero.Fs, value string) ([]byte, error) {
+	if value == "" || strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	if path.IsAbs(value) {
+		return afero.ReadFile(fs, value)
+	}
+
+	return afero.ReadFile(fs, path.Join(os.Getenv("GITHUB_WORKSPACE"), value))
+}
+
+// GetTrustedKeys loads public keys allowed to sign release tags either from a value or from a file
+func (c *Configuration) GetTrustedKeys(fs afero.Fs) (*release.TrustedKeys, error) {
+	b := []byte(c.TrustedKeys)
+	if !inlineKeys(c.TrustedKeys) {
+		var err error
+		b, err = loadPEM(fs, c.TrustedKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading trusted signing keys file")
+		}
+	}
+
+	return release.ParseTrustedKeys(b)
+}
+
+// inlineKeys reports whether a value contains a PEM/armored block or an SSH public key line ("<type> <key> [comment]")
+// rather than a path to a file
+func inlineKeys(value string) bool {
+	if strings.Contains(value, "-----BEGIN") {
+		return true
+	}
+
+	for _, line := range strings.Split(value, "\n") {
+		f := strings.Fields(line)
+		if len(f) >= 2 && (strings.HasPrefix(f[0], "ssh-") || strings.HasPrefix(f[0], "ecdsa-") || strings.HasPrefix(f[0], "sk-")) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *Configuration) GetChangelog(fs afero.Fs, rel *release.Release) (string, error) {
 	p, err := changelog.NewParserWithFilesystem(fs, c.ChangelogFile)
 	if err != nil {
@@ -111,6 +592,14 @@ func (c *Configuration) GetChangelog(fs afero.Fs, rel *release.Release) (string,
 		r := changes.GetRelease(rel.Reference.Version)
 
 		if r != nil {
+			rel.Date = r.Date
+
+			if c.CumulativeChangelog {
+				if cumulative := release.CumulativeChanges(changes.Releases, rel.Reference.Version); cumulative != nil {
+					return cumulative.ToString(), nil
+				}
+			}
+
 			if r.Changes != nil {
 				return r.Changes.ToString(), nil
 			} else {
@@ -121,6 +610,16 @@ func (c *Configuration) GetChangelog(fs afero.Fs, rel *release.Release) (string,
 		}
 	}
 
+	if c.GenerateNotes == release.GenerateNotesFallback {
+		log.Warnf("%v, falling back to generated release notes", msg)
+		return "", nil
+	}
+
+	if c.BodySource == release.BodySourceBoth {
+		log.Warnf("%v, using tag message only", msg)
+		return "", nil
+	}
+
 	if !c.AllowEmptyChangelog {
 		return "", errors.New(msg)
 	}
@@ -128,3 +627,19 @@ func (c *Configuration) GetChangelog(fs afero.Fs, rel *release.Release) (string,
 	log.Warn(msg)
 	return "", nil
 }
+
+// GetNotesConfig returns a path of release notes categories configuration file if it exists in the repository
+func (c *Configuration) GetNotesConfig(fs afero.Fs) (string, error) {
+	for _, f := range []string{".github/release.yml", ".github/release.yaml"} {
+		b, err := afero.Exists(fs, path.Join(os.Getenv("GITHUB_WORKSPACE"), f))
+		if err != nil {
+			return "", errors.Wrap(err, "error validating release notes configuration file")
+		}
+
+		if b {
+			return f, nil
+		}
+	}
+
+	return "", nil
+}
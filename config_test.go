@@ -0,0 +1,448 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+const workspace string = "/workspace"
+
+func TestGetNotesConfig(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Files    []string
+		Expected string
+	}
+
+	suite := map[string]test{
+		"Missing": {
+			Expected: "",
+		},
+		"YML": {
+			Files:    []string{".github/release.yml"},
+			Expected: ".github/release.yml",
+		},
+		"YAML": {
+			Files:    []string{".github/release.yaml"},
+			Expected: ".github/release.yaml",
+		},
+		"Both": {
+			Files:    []string{".github/release.yaml", ".github/release.yml"},
+			Expected: ".github/release.yml",
+		},
+		"Outside of Workspace": {
+			Files:    []string{"../.github/release.yml"},
+			Expected: "",
+		},
+	}
+
+	if err := os.Setenv("GITHUB_WORKSPACE", workspace); err != nil {
+		t.Fatalf("error preparing test case: error setting environmental variable GITHUB_WORKSPACE=%v: %v", workspace, err)
+	}
+	defer os.Unsetenv("GITHUB_WORKSPACE")
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		fs := afero.NewMemMapFs()
+		for _, f := range test.Files {
+			if err := afero.WriteFile(fs, workspace+"/"+f, []byte("changelog:\n"), 0644); err != nil {
+				t.Fatalf("error preparing test case: error creating file %v: %v", f, err)
+			}
+		}
+
+		conf := new(Configuration)
+
+		// test
+		config, err := conf.GetNotesConfig(fs)
+		a.Equal(nil, err)
+		a.Equal(test.Expected, config)
+	}
+}
+
+func TestGetTrustedKeys(t *testing.T) {
+	a := assert.New(t)
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating ed25519 key: %v", err)
+	}
+	sshKey, err := ssh.NewPublicKey(public)
+	if err != nil {
+		t.Fatalf("error preparing test case: error encoding ssh key: %v", err)
+	}
+	authorized := string(ssh.MarshalAuthorizedKey(sshKey))
+
+	type test struct {
+		TrustedKeys   string
+		Files         map[string]string
+		ExpectedSSH   int
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"Value": {
+			TrustedKeys: authorized,
+			ExpectedSSH: 1,
+		},
+		"File": {
+			TrustedKeys: "keys",
+			Files:       map[string]string{"keys": "# release signers\n" + authorized + authorized},
+			ExpectedSSH: 2,
+		},
+		"File Named After Key Type": {
+			TrustedKeys: ".github/ssh-signers",
+			Files:       map[string]string{".github/ssh-signers": authorized},
+			ExpectedSSH: 1,
+		},
+		"Missing File": {
+			TrustedKeys:   "keys",
+			ExpectedError: "error reading trusted signing keys file: open /workspace/keys: file does not exist",
+		},
+		"Empty File": {
+			TrustedKeys:   "keys",
+			Files:         map[string]string{"keys": "# release signers\n"},
+			ExpectedError: "trusted keys do not contain any pgp or ssh public key",
+		},
+		"Malformed SSH Key": {
+			TrustedKeys:   "ssh-ed25519 bad",
+			ExpectedError: "error parsing ssh public key 'ssh-ed25519 bad': ssh: no key found",
+		},
+		"Malformed PGP Key": {
+			TrustedKeys:   "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nbad\n-----END PGP PUBLIC KEY BLOCK-----",
+			ExpectedError: "error parsing pgp public key: unexpected EOF",
+		},
+	}
+
+	if err := os.Setenv("GITHUB_WORKSPACE", workspace); err != nil {
+		t.Fatalf("error preparing test case: error setting environmental variable GITHUB_WORKSPACE=%v: %v", workspace, err)
+	}
+	defer os.Unsetenv("GITHUB_WORKSPACE")
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		fs := afero.NewMemMapFs()
+		for f, content := range test.Files {
+			if err := afero.WriteFile(fs, workspace+"/"+f, []byte(content), 0644); err != nil {
+				t.Fatalf("error preparing test case: error creating file %v: %v", f, err)
+			}
+		}
+
+		conf := &Configuration{TrustedKeys: test.TrustedKeys}
+
+		// test
+		keys, err := conf.GetTrustedKeys(fs)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+			a.Nil(keys)
+		} else {
+			a.Len(keys.SSH, test.ExpectedSSH)
+		}
+	}
+}
+
+func TestGetVersionTag(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	changes := `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2024-03-01
+### Added
+- Feature B
+
+## [1.1.0] - 2024-02-01
+### Added
+- Feature A
+
+[Unreleased]: https://github.com/anton-yurchenko/git-release/compare/v1.2.0...HEAD
+[1.2.0]: https://github.com/anton-yurchenko/git-release/compare/v1.1.0...v1.2.0
+[1.1.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.1.0
+`
+
+	type test struct {
+		GitHubRef        string
+		Version          string
+		VersionTagPrefix string
+		Changelog        string
+		Expected         string
+		ExpectedError    string
+	}
+
+	suite := map[string]test{
+		"Not Set": {
+			GitHubRef: "refs/heads/master",
+			Expected:  "",
+		},
+		"Triggered by Tag": {
+			GitHubRef: "refs/tags/v1.0.0",
+			Version:   "v2.0.0",
+			Expected:  "",
+		},
+		"Tag": {
+			GitHubRef: "refs/heads/master",
+			Version:   "v2.0.0",
+			Expected:  "v2.0.0",
+		},
+		"Changelog": {
+			GitHubRef:        "refs/heads/master",
+			Version:          "changelog",
+			VersionTagPrefix: "v",
+			Changelog:        changes,
+			Expected:         "v1.2.0",
+		},
+		"Changelog without Prefix": {
+			GitHubRef: "refs/heads/master",
+			Version:   "changelog",
+			Changelog: changes,
+			Expected:  "1.2.0",
+		},
+		"Missing Changelog": {
+			GitHubRef:     "refs/heads/master",
+			Version:       "changelog",
+			ExpectedError: "VERSION is set to 'changelog' while changelog file not found",
+		},
+		"Changelog without Versions": {
+			GitHubRef:     "refs/heads/master",
+			Version:       "changelog",
+			Changelog:     "# Changelog\n\n## [Unreleased]\n",
+			ExpectedError: "changelog file does not contain versions",
+		},
+	}
+
+	defer os.Unsetenv("GITHUB_REF")
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		fs := afero.NewMemMapFs()
+
+		conf := &Configuration{
+			Version:          test.Version,
+			VersionTagPrefix: test.VersionTagPrefix,
+		}
+
+		if test.Changelog != "" {
+			conf.ChangelogFile = workspace + "/CHANGELOG.md"
+			if err := afero.WriteFile(fs, conf.ChangelogFile, []byte(test.Changelog), 0644); err != nil {
+				t.Fatalf("error preparing test case: error creating file %v: %v", conf.ChangelogFile, err)
+			}
+		}
+
+		if err := os.Setenv("GITHUB_REF", test.GitHubRef); err != nil {
+			t.Fatalf("error preparing test case: error setting environmental variable GITHUB_REF=%v: %v", test.GitHubRef, err)
+		}
+
+		// test
+		tag, err := conf.GetVersionTag(fs)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, tag)
+	}
+}
+
+func TestGetAppKey(t *testing.T) {
+	a := assert.New(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating rsa key: %v", err)
+	}
+	rsaPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating ecdsa key: %v", err)
+	}
+	b, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("error preparing test case: error encoding ecdsa key: %v", err)
+	}
+	ecPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}))
+
+	type test struct {
+		AppPrivateKey string
+		Files         map[string]string
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"Value": {
+			AppPrivateKey: rsaPEM,
+		},
+		"File": {
+			AppPrivateKey: "app.pem",
+			Files:         map[string]string{"app.pem": rsaPEM},
+		},
+		"File outside of Workspace": {
+			AppPrivateKey: "/runner/temp/app.pem",
+			Files:         map[string]string{"/runner/temp/app.pem": rsaPEM},
+		},
+		"Missing File": {
+			AppPrivateKey: "app.pem",
+			ExpectedError: "error reading private key file: open /workspace/app.pem: file does not exist",
+		},
+		"Malformed Key": {
+			AppPrivateKey: "app.pem",
+			Files:         map[string]string{"app.pem": "bad"},
+			ExpectedError: "error decoding signing key: PEM block not found",
+		},
+		"Not an RSA Key": {
+			AppPrivateKey: ecPEM,
+			ExpectedError: "GitHub App private key should be an RSA key",
+		},
+	}
+
+	if err := os.Setenv("GITHUB_WORKSPACE", workspace); err != nil {
+		t.Fatalf("error preparing test case: error setting environmental variable GITHUB_WORKSPACE=%v: %v", workspace, err)
+	}
+	defer os.Unsetenv("GITHUB_WORKSPACE")
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		fs := afero.NewMemMapFs()
+		for f, content := range test.Files {
+			if !path.IsAbs(f) {
+				f = workspace + "/" + f
+			}
+
+			if err := afero.WriteFile(fs, f, []byte(content), 0644); err != nil {
+				t.Fatalf("error preparing test case: error creating file %v: %v", f, err)
+			}
+		}
+
+		conf := &Configuration{AppPrivateKey: test.AppPrivateKey}
+
+		// test
+		key, err := conf.GetAppKey(fs)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+			a.Nil(key)
+		} else {
+			a.Equal(rsaKey, key)
+		}
+	}
+}
+
+func TestGetHTTPClient(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		CABundle      string
+		ClientCert    string
+		ClientKey     string
+		Files         map[string]string
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"Default": {},
+		"Missing CA Bundle File": {
+			CABundle:      "ca.pem",
+			ExpectedError: "error reading CA bundle file: open /workspace/ca.pem: file does not exist",
+		},
+		"Malformed CA Bundle": {
+			CABundle:      "-----BEGIN CERTIFICATE-----\nbad\n-----END CERTIFICATE-----",
+			ExpectedError: "error parsing CA bundle: PEM certificates not found",
+		},
+		"Malformed CA Bundle File": {
+			CABundle:      "ca.pem",
+			Files:         map[string]string{"ca.pem": "bad"},
+			ExpectedError: "error parsing CA bundle: PEM certificates not found",
+		},
+		"Malformed CA Bundle File outside of Workspace": {
+			CABundle:      "/tmp/ca.pem",
+			Files:         map[string]string{"/tmp/ca.pem": "bad"},
+			ExpectedError: "error parsing CA bundle: PEM certificates not found",
+		},
+		"Missing CA Bundle File outside of Workspace": {
+			CABundle:      "/tmp/ca.pem",
+			ExpectedError: "error reading CA bundle file: open /tmp/ca.pem: file does not exist",
+		},
+		"Missing Client Certificate File": {
+			ClientCert:    "client.pem",
+			ClientKey:     "client.key",
+			ExpectedError: "error reading client certificate file: open /workspace/client.pem: file does not exist",
+		},
+		"Missing Client Key File": {
+			ClientCert:    "client.pem",
+			ClientKey:     "client.key",
+			Files:         map[string]string{"client.pem": "bad"},
+			ExpectedError: "error reading client key file: open /workspace/client.key: file does not exist",
+		},
+		"Client Certificate without Key": {
+			ClientCert:    "-----BEGIN CERTIFICATE-----\nbad\n-----END CERTIFICATE-----",
+			ExpectedError: "error loading client certificate: tls: failed to find any PEM data in certificate input",
+		},
+	}
+
+	if err := os.Setenv("GITHUB_WORKSPACE", workspace); err != nil {
+		t.Fatalf("error preparing test case: error setting environmental variable GITHUB_WORKSPACE=%v: %v", workspace, err)
+	}
+	defer os.Unsetenv("GITHUB_WORKSPACE")
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		fs := afero.NewMemMapFs()
+		for f, content := range test.Files {
+			if !path.IsAbs(f) {
+				f = workspace + "/" + f
+			}
+
+			if err := afero.WriteFile(fs, f, []byte(content), 0644); err != nil {
+				t.Fatalf("error preparing test case: error creating file %v: %v", f, err)
+			}
+		}
+
+		conf := &Configuration{
+			CABundle:   test.CABundle,
+			ClientCert: test.ClientCert,
+			ClientKey:  test.ClientKey,
+		}
+
+		// test
+		c, err := conf.GetHTTPClient(fs)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+			a.Nil(c)
+		} else {
+			a.NotNil(c)
+		}
+	}
+}
@@ -2,31 +2,88 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
 
+	"git-release/release"
+
 	"github.com/google/go-github/github"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 	"golang.org/x/oauth2"
 )
 
-// Login to github.com and return authenticated client
-func Login(token string) (*github.Client, error) {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(context.Background(), ts)
+// Login to github.com and return authenticated client.
+// Client is authenticated as a GitHub App installation when APP_ID is set, by GITHUB_TOKEN otherwise.
+// Requests (including assets uploads) are sent through 'httpCli' transport.
+func Login(fs afero.Fs, conf *Configuration, httpCli *http.Client) (*github.Client, error) {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpCli)
+
+	ts, err := tokenSource(ctx, fs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(oauth2.NewClient(ctx, ts))
+}
+
+// tokenSource returns either a static GITHUB_TOKEN or auto-refreshing GitHub App installation tokens
+func tokenSource(ctx context.Context, fs afero.Fs, conf *Configuration) (oauth2.TokenSource, error) {
+	if conf.AppID == "" {
+		return oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: os.Getenv("GITHUB_TOKEN")},
+		), nil
+	}
+
+	key, err := conf.GetAppKey(fs)
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading GitHub App private key")
+	}
+
+	slug, err := release.GetSlug()
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := newClient(oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, &release.AppTokenSource{
+		AppID: conf.AppID,
+		Key:   key,
+	})))
+	if err != nil {
+		return nil, err
+	}
+
+	ts := oauth2.ReuseTokenSource(nil, &release.InstallationTokenSource{
+		Client:         app.Apps,
+		Slug:           slug,
+		InstallationID: conf.AppInstallationID,
+	})
+
+	// fail early on a misconfigured App instead of on the first API call
+	if _, err := ts.Token(); err != nil {
+		return nil, err
+	}
+	log.Infof("authenticated as GitHub App %v", conf.AppID)
 
-	if os.Getenv("GITHUB_API_URL") != "https://api.github.com" && os.Getenv("GITHUB_SERVER_URL") != "https://github.com" {
-		log.Info("running on GitHub Enterprise")
+	return ts, nil
+}
 
-		c, err := github.NewEnterpriseClient(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_SERVER_URL"), tc)
-		if err != nil {
-			return nil, errors.Wrap(err, "error connecting to a private github instance")
-		}
+// newClient returns a github.com or GitHub Enterprise client
+func newClient(tc *http.Client) (*github.Client, error) {
+	e, err := release.GetEndpoints(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_UPLOAD_URL"))
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Enterprise {
+		log.WithField("api", e.API).WithField("uploads", e.Upload).Info("running on GitHub Enterprise")
+	}
 
-		return c, nil
+	c, err := github.NewEnterpriseClient(e.API, e.Upload, tc)
+	if err != nil {
+		return nil, errors.Wrap(err, "error connecting to a private github instance")
 	}
 
-	return github.NewClient(tc), nil
+	return c, nil
 }
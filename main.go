@@ -1,9 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"git-release/release"
+	"strings"
 	"time"
 
+	"github.com/google/go-github/github"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 
@@ -15,6 +18,15 @@ import (
 // Version contains current application version
 const Version string = "6.0.0"
 
+const (
+	// CommandValidate validates a changelog file instead of publishing a release
+	CommandValidate string = "validate"
+	// CommandPrepare moves Unreleased changes into a new version and commits a changelog file
+	CommandPrepare string = "prepare"
+	// CommandPrune deletes releases matching retention rules
+	CommandPrune string = "prune"
+)
+
 func init() {
 	log.SetReportCaller(false)
 	log.SetFormatter(&log.TextFormatter{
@@ -27,7 +39,10 @@ func init() {
 	log.SetLevel(log.DebugLevel)
 
 	log.Debugf("git-release v%v ", Version)
+}
 
+// checkEnvironment terminates when environmental variables required by a command are not defined
+func checkEnvironment() {
 	l := []string{
 		"GITHUB_REPOSITORY",
 		"GITHUB_TOKEN",
@@ -38,7 +53,36 @@ func init() {
 		"GITHUB_SHA",
 	}
 
+	switch c, _ := command(); c {
+	case CommandValidate:
+		l = []string{
+			"GITHUB_WORKSPACE",
+		}
+	case CommandPrepare:
+		l = []string{
+			"GITHUB_REPOSITORY",
+			"GITHUB_TOKEN",
+			"GITHUB_WORKSPACE",
+			"GITHUB_API_URL",
+			"GITHUB_SERVER_URL",
+			"GITHUB_REF",
+		}
+	case CommandPrune:
+		l = []string{
+			"GITHUB_REPOSITORY",
+			"GITHUB_TOKEN",
+			"GITHUB_WORKSPACE",
+			"GITHUB_API_URL",
+			"GITHUB_SERVER_URL",
+		}
+	}
+
 	for _, v := range l {
+		// GitHub App credentials replace GITHUB_TOKEN
+		if v == "GITHUB_TOKEN" && os.Getenv("APP_ID") != "" {
+			continue
+		}
+
 		if os.Getenv(v) == "" {
 			log.Fatalf("%v is not defined", v)
 		}
@@ -46,6 +90,8 @@ func init() {
 }
 
 func main() {
+	checkEnvironment()
+
 	fs := afero.NewOsFs()
 
 	conf, err := GetConfig(fs)
@@ -53,31 +99,227 @@ func main() {
 		log.Fatal(errors.Wrap(err, "error fetching configuration"))
 	}
 
+	switch c, args := command(); c {
+	case CommandValidate:
+		if err := validate(fs, conf); err != nil {
+			log.Fatal(err)
+		}
+		return
+	case CommandPrepare:
+		if err := prepare(fs, conf, args); err != nil {
+			log.Fatal(err)
+		}
+		return
+	case CommandPrune:
+		if err := prune(fs, conf); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	versionTag, err := conf.GetVersionTag(fs)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "error resolving release version"))
+	}
+
 	rel, err := release.GetRelease(
 		fs,
 		os.Args[1:],
 		conf.TagPrefix,
+		conf.VersionScheme,
+		versionTag,
 		conf.ReleaseName,
 		conf.ReleaseNamePrefix,
 		conf.ReleaseNameSuffix,
-		conf.UnreleasedCreate || conf.UnreleasedDelete,
+		conf.Unreleased(),
 	)
 	if err != nil {
 		log.Fatal(errors.Wrap(err, "error fetching release configuration"))
 	}
+	rel.UpdateExisting = conf.UpdateExisting
 
-	if conf.ChangelogFile != "" {
-		rel.Changelog, err = conf.GetChangelog(fs, rel)
+	var event *github.RepositoryRelease
+	if conf.ReleaseEvent {
+		event, err = release.GetReleaseEvent(fs)
 		if err != nil {
-			log.Fatal(errors.Wrap(err, "error reading changelog"))
+			log.Fatal(errors.Wrap(err, "error reading release event"))
+		}
+	}
+
+	// release body is not needed when attaching assets to a release that already has one
+	body := event == nil || (conf.FillReleaseBody && event.GetBody() == "")
+
+	// previous releases are looked up among tags of the released component only
+	tagPrefix := conf.TagPrefix
+	if len(conf.Components) != 0 {
+		c, err := release.FindComponent(conf.Components, rel.Reference.Prefix)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error fetching component configuration"))
+		}
+		log.Infof("releasing component %v", c.Name)
+		tagPrefix = c.PrefixRegex()
+
+		if err := c.Apply(fs, rel); err != nil {
+			log.Fatal(err)
+		}
+
+		if c.Changelog != "" {
+			if err := conf.SetChangelogFile(fs, c.Changelog); err != nil {
+				log.Fatal(errors.Wrap(err, "error fetching configuration"))
+			}
 		}
 	}
 
-	cli, err := Login(os.Getenv("GITHUB_TOKEN"))
+	httpCli, err := conf.GetHTTPClient(fs)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "error configuring http client"))
+	}
+
+	cli, err := Login(fs, conf, httpCli)
 	if err != nil {
 		log.Fatal(errors.Wrap(err, "login error"))
 	}
 
+	// release body, generated notes and a previous release are resolved against a dated nightly tag
+	if conf.UnreleasedNightly {
+		if strings.HasPrefix(os.Getenv("GITHUB_REF"), fmt.Sprintf("refs/tags/%v", release.NightlyTagPrefix)) {
+			log.Fatal("workflow configuration error detected: trigger loop (nightly tags will trigger the workflow again)")
+		}
+
+		rel.Reference.Tag, err = rel.NightlyTag(cli.Git, time.Now().UTC())
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error resolving nightly tag"))
+		}
+
+		if conf.ReleaseName == "" {
+			rel.Name = fmt.Sprintf("%v%v%v", conf.ReleaseNamePrefix, rel.Reference.Tag, conf.ReleaseNameSuffix)
+		}
+	}
+
+	// fail fast before assets and changelog are generated
+	if conf.Preflight {
+		update := conf.UpdateExisting || conf.ReleaseEvent || conf.Unreleased()
+		if err := rel.Preflight(cli.Repositories, update); err != nil {
+			log.Fatal(errors.Wrap(err, "preflight check failed"))
+		}
+	}
+
+	if conf.SBOMFormat != "" {
+		if err := rel.GenerateSBOMs(fs, conf.SBOMFormat); err != nil {
+			log.Fatal(errors.Wrap(err, "error generating sbom"))
+		}
+	}
+
+	if conf.Provenance {
+		key, err := conf.GetSigningKey(fs)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error loading provenance signing key"))
+		}
+
+		if err := rel.GenerateProvenance(fs, os.Getenv("GITHUB_WORKSPACE"), key); err != nil {
+			log.Fatal(errors.Wrap(err, "error generating provenance"))
+		}
+	}
+
+	// expected API calls are counted once generated assets are attached
+	if conf.Preflight {
+		if err := rel.CheckRateLimit(cli); err != nil {
+			log.Fatal(errors.Wrap(err, "preflight check failed"))
+		}
+	}
+
+	// changelog is not a part of a body made of generated notes or a tag message only
+	changelogBody := body && conf.GenerateNotes != release.GenerateNotesOnly && conf.BodySource != release.BodySourceTag
+
+	if changelogBody && conf.ChangelogFile != "" {
+		rel.Changelog, err = conf.GetChangelog(fs, rel)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error reading changelog"))
+		}
+	}
+
+	// conventional commits stand in for a missing changelog file
+	if changelogBody && conf.ChangelogFile == "" && conf.ConventionalCommits {
+		rel.Changelog, err = rel.CommitsChangelog(
+			os.Getenv("GITHUB_WORKSPACE"),
+			tagPrefix,
+			fmt.Sprintf("%v/%v/%v", strings.TrimSuffix(os.Getenv("GITHUB_SERVER_URL"), "/"), rel.Slug.Owner, rel.Slug.Name),
+		)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error generating changelog from commits"))
+		}
+	}
+
+	if conf.RequireSignedTag {
+		keys, err := conf.GetTrustedKeys(fs)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error loading trusted signing keys"))
+		}
+
+		if err := rel.VerifyTag(cli.Git, keys); err != nil {
+			log.Fatal(errors.Wrap(err, "refusing to release"))
+		}
+	}
+
+	if conf.Policies != nil {
+		if err := rel.CheckPolicies(cli.Repositories, conf.Policies, tagPrefix, time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if versionTag != "" {
+		var msg string
+		if conf.AnnotatedTag {
+			msg = fmt.Sprintf("Release %v", rel.Reference.Tag)
+		}
+
+		if err := rel.CreateTag(cli.Git, msg); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if body && conf.BodySource != release.BodySourceChangelog && rel.Reference.Version != "Unreleased" {
+		msg, err := rel.TagMessage(cli.Git)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error reading tag message"))
+		}
+
+		if msg == "" {
+			if conf.BodySource == release.BodySourceTag && !conf.AllowEmptyChangelog && conf.GenerateNotes == "" {
+				log.Fatalf("tag %v does not contain a message", rel.Reference.Tag)
+			}
+			log.Warnf("tag %v does not contain a message", rel.Reference.Tag)
+		}
+
+		rel.Changelog = release.CombineBody(conf.BodySource, rel.Changelog, msg)
+	}
+
+	if body && conf.GenerateNotes != "" && (conf.GenerateNotes != release.GenerateNotesFallback || rel.Changelog == "") {
+		config, err := conf.GetNotesConfig(fs)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		notes, err := rel.GenerateNotes(cli, cli.Repositories, tagPrefix, config)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rel.Changelog = release.CombineNotes(conf.GenerateNotes, rel.Changelog, notes)
+	}
+
+	if conf.SkipUnchanged != "" && (conf.UnreleasedCreate || conf.UnreleasedRolling) {
+		unchanged, err := rel.Unchanged(fs, cli.Repositories, cli.Git, httpCli, conf.SkipUnchanged == release.SkipUnchangedContent)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error comparing precedent release"))
+		}
+
+		if unchanged {
+			log.Infof("%v release is up to date, skipping", rel.Name)
+			return
+		}
+	}
+
 	if conf.UnreleasedCreate || conf.UnreleasedDelete {
 		log.Warnf("deleting precedent release ❗")
 		err := rel.DeleteUnreleased(cli.Repositories, cli.Git)
@@ -95,8 +337,59 @@ func main() {
 		time.Sleep(3 * time.Second)
 	}
 
-	log.Infof("creating %v release", rel.Name)
-	if err := rel.Publish(cli.Repositories); err != nil {
-		log.Fatal(err)
+	if conf.UnreleasedNightly {
+		if err := rel.UpdateUnreleasedTag(cli.Git); err != nil {
+			log.Fatal(errors.Wrapf(err, "error creating %v tag", rel.Reference.Tag))
+		}
+	}
+
+	if conf.MakeLatest != "" && !conf.ReleaseEvent {
+		rel.MakeLatest, err = rel.ResolveMakeLatest(cli.Repositories, conf.MakeLatest, tagPrefix)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "error resolving latest release"))
+		}
 	}
+
+	if conf.ReleaseEvent {
+		log.Infof("attaching assets to %v release", event.GetTagName())
+		if err := rel.Attach(fs, cli.Repositories, httpCli, cli, event, conf.FillReleaseBody); err != nil {
+			log.Fatal(err)
+		}
+	} else if conf.UnreleasedRolling {
+		log.Infof("updating %v release", rel.Name)
+		if err := rel.PublishRolling(fs, cli.Repositories, cli.Git, httpCli, cli); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		log.Infof("creating %v release", rel.Name)
+		if err := rel.Publish(fs, cli.Repositories, httpCli, cli); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if conf.VerifyAssets {
+		log.Info("verifying uploaded assets")
+		if err := rel.VerifyAssets(fs, cli.Repositories, httpCli, conf.ReuploadCorrupted); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if conf.UnreleasedNightly && (conf.KeepNightlies != 0 || conf.NightlyMaxAge != 0) {
+		if err := rel.PruneNightlies(cli.Repositories, cli.Git, conf.KeepNightlies, time.Duration(conf.NightlyMaxAge)*24*time.Hour, time.Now().UTC()); err != nil {
+			log.Fatal(errors.Wrap(err, "error pruning nightly releases"))
+		}
+	}
+}
+
+// command returns a subcommand supplied as the first action argument followed by its arguments
+func command() (string, []string) {
+	args := release.SplitArguments(os.Args[1:])
+	if len(args) != 0 {
+		switch args[0] {
+		case CommandValidate, CommandPrepare, CommandPrune:
+			return args[0], args[1:]
+		}
+	}
+
+	return "", nil
 }
@@ -14,6 +14,70 @@ type GitClient struct {
 	mock.Mock
 }
 
+// CreateBlob provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *GitClient) CreateBlob(_a0 context.Context, _a1 string, _a2 string, _a3 *github.Blob) (*github.Blob, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *github.Blob
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.Blob) *github.Blob); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Blob)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.Blob) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.Blob) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateCommit provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *GitClient) CreateCommit(_a0 context.Context, _a1 string, _a2 string, _a3 *github.Commit) (*github.Commit, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *github.Commit
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.Commit) *github.Commit); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Commit)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.Commit) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.Commit) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreateRef provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *GitClient) CreateRef(_a0 context.Context, _a1 string, _a2 string, _a3 *github.Reference) (*github.Reference, *github.Response, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -46,6 +110,70 @@ func (_m *GitClient) CreateRef(_a0 context.Context, _a1 string, _a2 string, _a3
 	return r0, r1, r2
 }
 
+// CreateTag provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *GitClient) CreateTag(_a0 context.Context, _a1 string, _a2 string, _a3 *github.Tag) (*github.Tag, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *github.Tag
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.Tag) *github.Tag); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Tag)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.Tag) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.Tag) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateTree provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *GitClient) CreateTree(_a0 context.Context, _a1 string, _a2 string, _a3 string, _a4 []github.TreeEntry) (*github.Tree, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 *github.Tree
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []github.TreeEntry) *github.Tree); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Tree)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []github.TreeEntry) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, []github.TreeEntry) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeleteRef provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *GitClient) DeleteRef(_a0 context.Context, _a1 string, _a2 string, _a3 string) (*github.Response, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -69,6 +197,38 @@ func (_m *GitClient) DeleteRef(_a0 context.Context, _a1 string, _a2 string, _a3
 	return r0, r1
 }
 
+// GetCommit provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *GitClient) GetCommit(_a0 context.Context, _a1 string, _a2 string, _a3 string) (*github.Commit, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *github.Commit
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *github.Commit); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Commit)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetRef provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *GitClient) GetRef(_a0 context.Context, _a1 string, _a2 string, _a3 string) (*github.Reference, *github.Response, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -100,3 +260,67 @@ func (_m *GitClient) GetRef(_a0 context.Context, _a1 string, _a2 string, _a3 str
 
 	return r0, r1, r2
 }
+
+// GetTag provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *GitClient) GetTag(_a0 context.Context, _a1 string, _a2 string, _a3 string) (*github.Tag, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *github.Tag
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *github.Tag); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Tag)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateRef provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *GitClient) UpdateRef(_a0 context.Context, _a1 string, _a2 string, _a3 *github.Reference, _a4 bool) (*github.Reference, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 *github.Reference
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.Reference, bool) *github.Reference); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Reference)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.Reference, bool) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.Reference, bool) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
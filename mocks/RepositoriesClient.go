@@ -8,6 +8,8 @@ import (
 	github "github.com/google/go-github/github"
 	mock "github.com/stretchr/testify/mock"
 
+	io "io"
+
 	os "os"
 )
 
@@ -16,6 +18,38 @@ type RepositoriesClient struct {
 	mock.Mock
 }
 
+// CompareCommits provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *RepositoriesClient) CompareCommits(_a0 context.Context, _a1 string, _a2 string, _a3 string, _a4 string) (*github.CommitsComparison, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 *github.CommitsComparison
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *github.CommitsComparison); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.CommitsComparison)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, string) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreateRelease provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *RepositoriesClient) CreateRelease(_a0 context.Context, _a1 string, _a2 string, _a3 *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -94,6 +128,100 @@ func (_m *RepositoriesClient) DeleteReleaseAsset(_a0 context.Context, _a1 string
 	return r0, r1
 }
 
+// DownloadReleaseAsset provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *RepositoriesClient) DownloadReleaseAsset(_a0 context.Context, _a1 string, _a2 string, _a3 int64) (io.ReadCloser, string, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) io.ReadCloser); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) string); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EditRelease provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *RepositoriesClient) EditRelease(_a0 context.Context, _a1 string, _a2 string, _a3 int64, _a4 *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 *github.RepositoryRelease
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *github.RepositoryRelease) *github.RepositoryRelease); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryRelease)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, *github.RepositoryRelease) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64, *github.RepositoryRelease) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Get provides a mock function with given fields: _a0, _a1, _a2
+func (_m *RepositoriesClient) Get(_a0 context.Context, _a1 string, _a2 string) (*github.Repository, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *github.Repository
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *github.Repository); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Repository)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetReleaseByTag provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *RepositoriesClient) GetReleaseByTag(_a0 context.Context, _a1 string, _a2 string, _a3 string) (*github.RepositoryRelease, *github.Response, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -126,6 +254,70 @@ func (_m *RepositoriesClient) GetReleaseByTag(_a0 context.Context, _a1 string, _
 	return r0, r1, r2
 }
 
+// ListReleaseAssets provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *RepositoriesClient) ListReleaseAssets(_a0 context.Context, _a1 string, _a2 string, _a3 int64, _a4 *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 []*github.ReleaseAsset
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *github.ListOptions) []*github.ReleaseAsset); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.ReleaseAsset)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, *github.ListOptions) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64, *github.ListOptions) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListReleases provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *RepositoriesClient) ListReleases(_a0 context.Context, _a1 string, _a2 string, _a3 *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []*github.RepositoryRelease
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.ListOptions) []*github.RepositoryRelease); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.RepositoryRelease)
+		}
+	}
+
+	var r1 *github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.ListOptions) *github.Response); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.ListOptions) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UploadReleaseAsset provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4, _a5
 func (_m *RepositoriesClient) UploadReleaseAsset(_a0 context.Context, _a1 string, _a2 string, _a3 int64, _a4 *github.UploadOptions, _a5 *os.File) (*github.ReleaseAsset, *github.Response, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3, _a4, _a5)
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"git-release/release"
+
+	changelog "github.com/anton-yurchenko/go-changelog"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// prepare moves Unreleased changes into a new version and commits the changelog file to the current branch
+func prepare(fs afero.Fs, conf *Configuration, args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return errors.New("prepare command expects a single version argument, for example 'prepare v1.2.0'")
+	}
+
+	if conf.ChangelogFile == "" {
+		return errors.New("changelog file not found")
+	}
+
+	if !strings.HasPrefix(os.Getenv("GITHUB_REF"), "refs/heads/") {
+		return errors.New(fmt.Sprintf("prepare command should be executed on a branch, got GITHUB_REF '%v'", os.Getenv("GITHUB_REF")))
+	}
+	branch := strings.TrimPrefix(os.Getenv("GITHUB_REF"), "refs/heads/")
+
+	if os.Getenv("GITHUB_SHA") == "" {
+		return errors.New("GITHUB_SHA is not defined")
+	}
+
+	ref, err := release.ParseTag(args[0], conf.TagPrefix, conf.VersionScheme)
+	if err != nil {
+		return err
+	}
+	ref.CommitHash = os.Getenv("GITHUB_SHA")
+
+	slug, err := release.GetSlug()
+	if err != nil {
+		return err
+	}
+
+	rel := &release.Release{
+		Slug:      slug,
+		Reference: ref,
+	}
+
+	b, err := afero.ReadFile(fs, conf.ChangelogFile)
+	if err != nil {
+		return errors.Wrap(err, "error reading changelog file")
+	}
+
+	content, err := release.PromoteUnreleased(
+		string(b),
+		ref.Version,
+		ref.Tag,
+		time.Now().UTC().Format(changelog.DateFormat),
+		fmt.Sprintf("%v/%v/%v", strings.TrimSuffix(os.Getenv("GITHUB_SERVER_URL"), "/"), slug.Owner, slug.Name),
+	)
+	if err != nil {
+		return errors.Wrap(err, "error updating changelog")
+	}
+
+	if err := afero.WriteFile(fs, conf.ChangelogFile, []byte(content), 0644); err != nil {
+		return errors.Wrap(err, "error writing changelog file")
+	}
+
+	file, err := filepath.Rel(os.Getenv("GITHUB_WORKSPACE"), conf.ChangelogFile)
+	if err != nil {
+		return errors.Wrap(err, "error resolving changelog file path")
+	}
+
+	httpCli, err := conf.GetHTTPClient(fs)
+	if err != nil {
+		return errors.Wrap(err, "error configuring http client")
+	}
+
+	cli, err := Login(fs, conf, httpCli)
+	if err != nil {
+		return errors.Wrap(err, "login error")
+	}
+
+	return rel.CommitChangelog(cli.Git, branch, filepath.ToSlash(file), content, conf.PrepareTag)
+}
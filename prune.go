@@ -0,0 +1,60 @@
+package main
+
+import (
+	"time"
+
+	"git-release/release"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// prune deletes releases matching retention rules
+func prune(fs afero.Fs, conf *Configuration) error {
+	if conf.PruneRules == nil {
+		return errors.New("prune command expects at least one of PRUNE_KEEP_PER_LINE, PRUNE_PRERELEASE_AGE, PRUNE_DRAFT_AGE")
+	}
+
+	slug, err := release.GetSlug()
+	if err != nil {
+		return err
+	}
+
+	rel := &release.Release{
+		Slug: slug,
+	}
+
+	httpCli, err := conf.GetHTTPClient(fs)
+	if err != nil {
+		return errors.Wrap(err, "error configuring http client")
+	}
+
+	cli, err := Login(fs, conf, httpCli)
+	if err != nil {
+		return errors.Wrap(err, "login error")
+	}
+
+	releases, err := rel.ListReleases(cli.Repositories)
+	if err != nil {
+		return err
+	}
+
+	candidates := release.PruneCandidates(releases, conf.TagPrefix, conf.VersionScheme, conf.PruneRules, time.Now().UTC())
+	if len(candidates) == 0 {
+		log.Info("nothing to prune")
+		return nil
+	}
+
+	if err := rel.Prune(cli.Repositories, cli.Git, candidates, conf.PruneTags, conf.PruneDryRun); err != nil {
+		return err
+	}
+
+	if conf.PruneDryRun {
+		log.Infof("%v release(s) would be deleted", len(candidates))
+	} else {
+		log.Infof("%v release(s) deleted 🎉", len(candidates))
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package release
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// AppsClient is a GitHub Apps API client authenticated as an App (by a JWT)
+type AppsClient interface {
+	FindRepositoryInstallation(context.Context, string, string) (*github.Installation, *github.Response, error)
+	CreateInstallationToken(context.Context, int64) (*github.InstallationToken, *github.Response, error)
+}
+
+// ParseAppKey parses a PEM encoded RSA private key of a GitHub App
+func ParseAppKey(data []byte) (*rsa.PrivateKey, error) {
+	key, err := ParseSigningKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	k, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key should be an RSA key")
+	}
+
+	return k, nil
+}
+
+// AppJWT returns a JWT of an App valid for 9 minutes since 'now' (GitHub accepts up to 10 minutes)
+func AppJWT(appID string, key *rsa.PrivateKey, now time.Time) (string, time.Time, error) {
+	expiry := now.Add(9 * time.Minute)
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", expiry, err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		// backdated to tolerate a clock drift
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": expiry.Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", expiry, err
+	}
+
+	unsigned := fmt.Sprintf("%v.%v", base64.RawURLEncoding.EncodeToString(header), base64.RawURLEncoding.EncodeToString(claims))
+
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", expiry, errors.Wrap(err, "error signing JWT")
+	}
+
+	return fmt.Sprintf("%v.%v", unsigned, base64.RawURLEncoding.EncodeToString(signature)), expiry, nil
+}
+
+// AppTokenSource provides App JWTs
+type AppTokenSource struct {
+	AppID string
+	Key   *rsa.PrivateKey
+}
+
+// Token returns a new App JWT
+func (s *AppTokenSource) Token() (*oauth2.Token, error) {
+	jwt, expiry, err := AppJWT(s.AppID, s.Key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: jwt,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
+}
+
+// InstallationTokenSource exchanges App JWTs for installation access tokens.
+// An installation of a repository is looked up unless 'InstallationID' is set.
+// Wrap it with oauth2.ReuseTokenSource in order to refresh a token only once it expires.
+type InstallationTokenSource struct {
+	Client         AppsClient
+	Slug           *Slug
+	InstallationID int64
+}
+
+// Token returns a new installation access token
+func (s *InstallationTokenSource) Token() (*oauth2.Token, error) {
+	if s.InstallationID == 0 {
+		installation, _, err := s.Client.FindRepositoryInstallation(
+			context.Background(),
+			s.Slug.Owner,
+			s.Slug.Name,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error finding GitHub App installation of %v/%v", s.Slug.Owner, s.Slug.Name)
+		}
+		s.InstallationID = installation.GetID()
+	}
+
+	token, _, err := s.Client.CreateInstallationToken(
+		context.Background(),
+		s.InstallationID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating GitHub App installation %v token", s.InstallationID)
+	}
+	log.Debugf("GitHub App installation token issued, expires at %v", token.GetExpiresAt().Format(time.RFC3339))
+
+	t := &oauth2.Token{
+		AccessToken: token.GetToken(),
+		TokenType:   "Bearer",
+	}
+
+	if !token.GetExpiresAt().IsZero() {
+		// refreshed slightly ahead of time, so that a request in flight is not rejected
+		t.Expiry = token.GetExpiresAt().Add(-time.Minute)
+	}
+
+	return t, nil
+}
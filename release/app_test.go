@@ -0,0 +1,214 @@
+package release_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppKey(t *testing.T) {
+	a := assert.New(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating rsa key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating ecdsa key: %v", err)
+	}
+
+	ecBytes, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("error preparing test case: error marshaling ecdsa key: %v", err)
+	}
+
+	type test struct {
+		Key           []byte
+		Expected      *rsa.PrivateKey
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"RSA Key": {
+			Key:      pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			Expected: rsaKey,
+		},
+		"ECDSA Key": {
+			Key:           pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecBytes}),
+			ExpectedError: "GitHub App private key should be an RSA key",
+		},
+		"Malformed Key": {
+			Key:           []byte("key"),
+			ExpectedError: "error decoding signing key: PEM block not found",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// test
+		key, err := release.ParseAppKey(test.Key)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, key)
+	}
+}
+
+func TestAppJWT(t *testing.T) {
+	a := assert.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating rsa key: %v", err)
+	}
+
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	// test
+	jwt, expiry, err := release.AppJWT("12345", key, now)
+	a.Equal(nil, err)
+	a.Equal(now.Add(9*time.Minute), expiry)
+
+	parts := strings.Split(jwt, ".")
+	if !a.Len(parts, 3) {
+		return
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	a.Equal(nil, err)
+	a.JSONEq(`{"alg":"RS256","typ":"JWT"}`, string(header))
+
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	a.Equal(nil, err)
+	claims := make(map[string]interface{})
+	a.Equal(nil, json.Unmarshal(b, &claims))
+	a.Equal(map[string]interface{}{
+		"iat": float64(now.Add(-time.Minute).Unix()),
+		"exp": float64(now.Add(9 * time.Minute).Unix()),
+		"iss": "12345",
+	}, claims)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	a.Equal(nil, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	a.Equal(nil, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+}
+
+func TestInstallationTokenSource(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	expiry := time.Date(2024, 3, 5, 13, 0, 0, 0, time.UTC)
+
+	type test struct {
+		InstallationID       int64
+		FindError            error
+		CreateTokenError     error
+		ExpectedFind         bool
+		ExpectedCreateToken  bool
+		ExpectedToken        string
+		ExpectedExpiry       time.Time
+		ExpectedError        string
+		ExpectedInstallation int64
+	}
+
+	suite := map[string]test{
+		"Repository Installation": {
+			ExpectedFind:         true,
+			ExpectedCreateToken:  true,
+			ExpectedToken:        "token",
+			ExpectedExpiry:       expiry.Add(-time.Minute),
+			ExpectedInstallation: 10,
+		},
+		"Configured Installation": {
+			InstallationID:       20,
+			ExpectedCreateToken:  true,
+			ExpectedToken:        "token",
+			ExpectedExpiry:       expiry.Add(-time.Minute),
+			ExpectedInstallation: 20,
+		},
+		"Installation Not Found": {
+			FindError:     errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release/installation: 404 Not Found []"),
+			ExpectedFind:  true,
+			ExpectedError: "error finding GitHub App installation of anton-yurchenko/git-release: GET https://api.github.com/repos/anton-yurchenko/git-release/installation: 404 Not Found []",
+		},
+		"Token Error": {
+			InstallationID:       20,
+			CreateTokenError:     errors.New("reason"),
+			ExpectedCreateToken:  true,
+			ExpectedError:        "error creating GitHub App installation 20 token: reason",
+			ExpectedInstallation: 20,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		slug := &release.Slug{
+			Owner: "anton-yurchenko",
+			Name:  "git-release",
+		}
+
+		// test
+		m := new(mocks.AppsClient)
+
+		if test.ExpectedFind {
+			m.On("FindRepositoryInstallation",
+				context.Background(),
+				slug.Owner,
+				slug.Name).Return(&github.Installation{ID: int64P(10)}, nil, test.FindError).Once()
+		}
+
+		if test.ExpectedCreateToken {
+			m.On("CreateInstallationToken",
+				context.Background(),
+				test.ExpectedInstallation).Return(&github.InstallationToken{
+				Token:     stringP("token"),
+				ExpiresAt: &expiry,
+			}, nil, test.CreateTokenError).Once()
+		}
+
+		ts := &release.InstallationTokenSource{
+			Client:         m,
+			Slug:           slug,
+			InstallationID: test.InstallationID,
+		}
+
+		token, err := ts.Token()
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		} else {
+			a.Equal(test.ExpectedToken, token.AccessToken)
+			a.Equal(test.ExpectedExpiry, token.Expiry)
+			a.Equal(test.ExpectedInstallation, ts.InstallationID)
+		}
+		m.AssertExpectations(t)
+	}
+}
@@ -21,23 +21,8 @@ import (
 // GetAssets returns validated assets supplied via 'args'
 func GetAssets(fs afero.Fs, args []string) (*[]Asset, error) {
 	assets := make([]Asset, 0)
-	arguments := make([]string, 0)
-
-	for _, arg := range args {
-		if len(strings.Split(arg, " ")) > 1 {
-			arguments = append(arguments, strings.Split(arg, " ")...)
-		} else if len(strings.Split(arg, "\n")) > 1 {
-			arguments = append(arguments, strings.Split(arg, "\n")...)
-		} else if len(strings.Split(arg, ",")) > 1 {
-			arguments = append(arguments, strings.Split(arg, ",")...)
-		} else if len(strings.Split(arg, "|")) > 1 {
-			arguments = append(arguments, strings.Split(arg, "|")...)
-		} else {
-			arguments = append(arguments, arg)
-		}
-	}
 
-	for _, argument := range arguments {
+	for _, argument := range SplitArguments(args) {
 		files, err := afero.Glob(fs, filepath.Clean(argument))
 		if err != nil {
 			return nil, err
@@ -57,6 +42,27 @@ func GetAssets(fs afero.Fs, args []string) (*[]Asset, error) {
 	return &assets, nil
 }
 
+// SplitArguments splits action arguments divided by one of: new line, space, comma, pipe
+func SplitArguments(args []string) []string {
+	arguments := make([]string, 0)
+
+	for _, arg := range args {
+		if len(strings.Split(arg, " ")) > 1 {
+			arguments = append(arguments, strings.Split(arg, " ")...)
+		} else if len(strings.Split(arg, "\n")) > 1 {
+			arguments = append(arguments, strings.Split(arg, "\n")...)
+		} else if len(strings.Split(arg, ",")) > 1 {
+			arguments = append(arguments, strings.Split(arg, ",")...)
+		} else if len(strings.Split(arg, "|")) > 1 {
+			arguments = append(arguments, strings.Split(arg, "|")...)
+		} else {
+			arguments = append(arguments, arg)
+		}
+	}
+
+	return arguments
+}
+
 // Upload an asset to a GitHub release
 func (a *Asset) Upload(release *Release, cli RepositoriesClient, id int64, errs chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
@@ -91,6 +97,54 @@ func (a *Asset) Upload(release *Release, cli RepositoriesClient, id int64, errs
 	}
 }
 
+// Find returns an uploaded release asset with the same name
+func (a *Asset) Find(uploaded []github.ReleaseAsset) *github.ReleaseAsset {
+	for i, s := range uploaded {
+		if s.GetName() == a.uploadName() {
+			return &uploaded[i]
+		}
+	}
+
+	return nil
+}
+
+// Identical compares an asset with an uploaded release asset by name, size and SHA-256 digest of the content.
+// Partially uploaded release assets are never identical. The 'digest' reported by the API is used when there is one,
+// otherwise the content is downloaded when sizes match.
+func (a *Asset) Identical(fs afero.Fs, release *Release, cli RepositoriesClient, httpCli *http.Client, remote *github.ReleaseAsset, digest string) (bool, error) {
+	info, err := fs.Stat(a.Path)
+	if err != nil {
+		return false, errors.Wrap(err, "error opening a file")
+	}
+
+	if remote.GetName() != a.uploadName() ||
+		remote.GetState() != "uploaded" ||
+		int64(remote.GetSize()) != info.Size() {
+		return false, nil
+	}
+
+	local, err := a.Digest(fs)
+	if err != nil {
+		return false, err
+	}
+
+	if digest != "" {
+		return local == digest, nil
+	}
+
+	actual, err := release.remoteDigest(cli, httpCli, remote.GetID())
+	if err != nil {
+		return false, errors.Wrapf(err, "error downloading release asset %v", a.Name)
+	}
+
+	return local == actual, nil
+}
+
+// uploadName returns an asset name as it is stored on a release
+func (a *Asset) uploadName() string {
+	return strings.ReplaceAll(a.Name, "/", "-")
+}
+
 func (a *Asset) uploadHandler(release *Release, cli RepositoriesClient, id int64, lastTry bool) error {
 	file, err := os.Open(a.Path)
 	if err != nil {
@@ -103,7 +157,7 @@ func (a *Asset) uploadHandler(release *Release, cli RepositoriesClient, id int64
 		release.Slug.Name,
 		id,
 		&github.UploadOptions{
-			Name: strings.ReplaceAll(a.Name, "/", "-"),
+			Name: a.uploadName(),
 		},
 		file,
 	)
@@ -127,7 +181,7 @@ func (a *Asset) uploadHandler(release *Release, cli RepositoriesClient, id int64
 			}
 
 			for _, s := range rel.Assets {
-				if *s.Name == strings.ReplaceAll(a.Name, "/", "-") {
+				if *s.Name == a.uploadName() {
 					_, err = cli.DeleteReleaseAsset(
 						context.Background(),
 						release.Slug.Owner,
@@ -0,0 +1,123 @@
+package release
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	changelog "github.com/anton-yurchenko/go-changelog"
+	"golang.org/x/mod/semver"
+)
+
+// CumulativeChanges merges changes of all versions released after the previous stable version up to 'version' (inclusive).
+// Changes of a pre-release version are returned as is.
+func CumulativeChanges(releases changelog.Releases, version string) *changelog.Changes {
+	current := releases.GetRelease(version)
+	if current == nil {
+		return nil
+	}
+
+	v := fmt.Sprintf("v%v", version)
+	if semver.Prerelease(v) != "" {
+		return current.Changes
+	}
+
+	// find previous stable version
+	var previous string
+	for _, r := range releases {
+		p := fmt.Sprintf("v%v", *r.Version)
+		if semver.Prerelease(p) == "" && semver.Compare(p, v) < 0 {
+			if previous == "" || semver.Compare(p, previous) > 0 {
+				previous = p
+			}
+		}
+	}
+
+	included := make(changelog.Releases, 0)
+	for _, r := range releases {
+		p := fmt.Sprintf("v%v", *r.Version)
+		if semver.Compare(p, v) <= 0 && (previous == "" || semver.Compare(p, previous) > 0) {
+			included = append(included, r)
+		}
+	}
+	sort.Sort(sort.Reverse(included))
+
+	var merged *changelog.Changes
+	for _, r := range included {
+		if r.Changes == nil {
+			continue
+		}
+
+		if merged == nil {
+			merged = new(changelog.Changes)
+		}
+		mergeChanges(merged, r.Changes)
+	}
+
+	return merged
+}
+
+// mergeChanges appends scoped entries of 'src' to 'dst' skipping duplicates
+func mergeChanges(dst, src *changelog.Changes) {
+	if src.Notice != nil && *src.Notice != "" {
+		if dst.Notice == nil {
+			n := *src.Notice
+			dst.Notice = &n
+		} else if !strings.Contains(*dst.Notice, *src.Notice) {
+			n := fmt.Sprintf("%v\n\n%v", *dst.Notice, *src.Notice)
+			dst.Notice = &n
+		}
+	}
+
+	scopes := map[string]*[]string{
+		"added":      src.Added,
+		"changed":    src.Changed,
+		"deprecated": src.Deprecated,
+		"removed":    src.Removed,
+		"fixed":      src.Fixed,
+		"security":   src.Security,
+	}
+
+	for scope, entries := range scopes {
+		if entries == nil {
+			continue
+		}
+
+		for _, e := range *entries {
+			if !containsChange(dst, scope, e) {
+				// scope is always valid
+				_ = dst.AddChange(scope, e)
+			}
+		}
+	}
+}
+
+func containsChange(c *changelog.Changes, scope, change string) bool {
+	var entries *[]string
+	switch scope {
+	case "added":
+		entries = c.Added
+	case "changed":
+		entries = c.Changed
+	case "deprecated":
+		entries = c.Deprecated
+	case "removed":
+		entries = c.Removed
+	case "fixed":
+		entries = c.Fixed
+	case "security":
+		entries = c.Security
+	}
+
+	if entries == nil {
+		return false
+	}
+
+	for _, e := range *entries {
+		if e == change {
+			return true
+		}
+	}
+
+	return false
+}
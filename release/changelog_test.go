@@ -0,0 +1,114 @@
+package release_test
+
+import (
+	"testing"
+
+	"git-release/release"
+
+	changelog "github.com/anton-yurchenko/go-changelog"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const cumulativeChangelog string = `# Changelog
+
+## [Unreleased]
+
+## [2.0.0] - 2024-03-01
+
+### Fixed
+
+- Bug C
+
+## [2.0.0-rc.2] - 2024-02-20
+
+### Added
+
+- Feature B
+
+### Fixed
+
+- Bug B
+- Bug A
+
+## [2.0.0-rc.1] - 2024-02-10
+
+### Added
+
+- Feature A
+
+### Fixed
+
+- Bug A
+
+## [1.1.0] - 2024-01-10
+
+### Added
+
+- Feature Z
+
+[Unreleased]: https://github.com/anton-yurchenko/git-release/compare/v2.0.0...HEAD
+[2.0.0]: https://github.com/anton-yurchenko/git-release/compare/v2.0.0-rc.2...v2.0.0
+[2.0.0-rc.2]: https://github.com/anton-yurchenko/git-release/compare/v2.0.0-rc.1...v2.0.0-rc.2
+[2.0.0-rc.1]: https://github.com/anton-yurchenko/git-release/compare/v1.1.0...v2.0.0-rc.1
+[1.1.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.1.0
+`
+
+func TestCumulativeChanges(t *testing.T) {
+	a := assert.New(t)
+
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "CHANGELOG.md", []byte(cumulativeChangelog), 0644); err != nil {
+		t.Fatalf("error preparing test case: error creating file CHANGELOG.md: %v", err)
+	}
+
+	p, err := changelog.NewParserWithFilesystem(fs, "CHANGELOG.md")
+	if err != nil {
+		t.Fatalf("error preparing test case: error loading changelog: %v", err)
+	}
+
+	c, err := p.Parse()
+	if err != nil {
+		t.Fatalf("error preparing test case: error parsing changelog: %v", err)
+	}
+
+	type test struct {
+		Version  string
+		Expected *changelog.Changes
+	}
+
+	suite := map[string]test{
+		"Stable Release": {
+			Version: "2.0.0",
+			Expected: &changelog.Changes{
+				Added: &[]string{"Feature B", "Feature A"},
+				Fixed: &[]string{"Bug C", "Bug B", "Bug A"},
+			},
+		},
+		"Pre-Release": {
+			Version: "2.0.0-rc.2",
+			Expected: &changelog.Changes{
+				Added: &[]string{"Feature B"},
+				Fixed: &[]string{"Bug B", "Bug A"},
+			},
+		},
+		"First Stable Release": {
+			Version: "1.1.0",
+			Expected: &changelog.Changes{
+				Added: &[]string{"Feature Z"},
+			},
+		},
+		"Missing Version": {
+			Version:  "3.0.0",
+			Expected: nil,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a.Equal(test.Expected, release.CumulativeChanges(c.Releases, test.Version))
+	}
+}
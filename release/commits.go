@@ -0,0 +1,249 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ConventionalCommitRegex string = `^(?P<type>[a-zA-Z]+)(?:\((?P<scope>[^()]*)\))?(?P<breaking>!)?: (?P<subject>.+)$`
+	BreakingChangeRegex     string = `(?m)^BREAKING[ -]CHANGE: (?P<description>.+)$`
+	IssueRegex              string = `(^|[^\w\[/])#(\d+)\b`
+)
+
+// ConventionalCommit is a commit message following Conventional Commits specification
+type ConventionalCommit struct {
+	Hash    string
+	Type    string
+	Scope   string
+	Subject string
+
+	// Breaking contains a description of a breaking change
+	Breaking string
+}
+
+// conventionalSections defines release notes sections by commit type in order of appearance
+var conventionalSections = []struct {
+	Type  string
+	Title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"refactor", "Code Refactoring"},
+}
+
+// ParseConventionalCommit parses a commit message, nil is returned for non-conventional messages
+func ParseConventionalCommit(hash, message string) *ConventionalCommit {
+	lines := strings.SplitN(strings.TrimSpace(message), "\n", 2)
+
+	regex := regexp.MustCompile(ConventionalCommitRegex)
+	m := regex.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if m == nil {
+		return nil
+	}
+
+	c := &ConventionalCommit{
+		Hash:    hash,
+		Type:    strings.ToLower(m[regex.SubexpIndex("type")]),
+		Scope:   m[regex.SubexpIndex("scope")],
+		Subject: strings.TrimSpace(m[regex.SubexpIndex("subject")]),
+	}
+
+	if len(lines) > 1 {
+		if b := regexp.MustCompile(BreakingChangeRegex).FindStringSubmatch(lines[1]); b != nil {
+			c.Breaking = strings.TrimSpace(b[1])
+		}
+	}
+
+	if c.Breaking == "" && m[regex.SubexpIndex("breaking")] != "" {
+		c.Breaking = c.Subject
+	}
+
+	return c
+}
+
+// CommitsChangelog builds release notes out of Conventional Commits found between the previous version tag
+// and a release commit in a local repository 'dir'.
+// 'repository' is used as a base for commits and issues links.
+func (r *Release) CommitsChangelog(dir, prefix, repository string) (string, error) {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "error opening git repository")
+	}
+
+	head, err := repo.CommitObject(plumbing.NewHash(r.Reference.CommitHash))
+	if err != nil {
+		return "", errors.Wrapf(err, "error retrieving commit %v", r.Reference.CommitHash)
+	}
+
+	previous, err := r.previousTag(repo, head, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	excluded := make(map[plumbing.Hash]bool)
+	if previous != nil {
+		log.Infof("collecting commits since %v", previous.Name().Short())
+
+		c, err := resolveTag(repo, previous)
+		if err != nil {
+			return "", err
+		}
+
+		err = object.NewCommitPreorderIter(c, nil, nil).ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "error reading git history (make sure the repository is checked out with full history)")
+		}
+	}
+
+	commits := make([]*ConventionalCommit, 0)
+	err = object.NewCommitPreorderIter(head, excluded, nil).ForEach(func(c *object.Commit) error {
+		if c.NumParents() > 1 {
+			return nil
+		}
+
+		if cc := ParseConventionalCommit(c.Hash.String(), c.Message); cc != nil {
+			commits = append(commits, cc)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "error reading git history (make sure the repository is checked out with full history)")
+	}
+
+	return ConventionalChangelog(commits, repository), nil
+}
+
+// previousTag returns the latest version tag (older than the release version) of an ancestor of 'head'
+func (r *Release) previousTag(repo *git.Repository, head *object.Commit, prefix string) (*plumbing.Reference, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing git tags")
+	}
+
+	var previous *plumbing.Reference
+	var version string
+	err = tags.ForEach(func(t *plumbing.Reference) error {
+		if t.Name().Short() == r.Reference.Tag {
+			return nil
+		}
+
+		ref, err := ParseTag(t.Name().Short(), prefix, r.versionScheme())
+		if err != nil {
+			return nil
+		}
+
+		if r.Reference.Version != "Unreleased" && r.versionScheme().Compare(ref.Version, r.Reference.Version) >= 0 {
+			return nil
+		}
+
+		if version != "" && r.versionScheme().Compare(ref.Version, version) <= 0 {
+			return nil
+		}
+
+		c, err := resolveTag(repo, t)
+		if err != nil {
+			return err
+		}
+
+		if c.Hash == head.Hash {
+			return nil
+		}
+
+		ok, err := c.IsAncestor(head)
+		if err != nil {
+			return errors.Wrapf(err, "error comparing %v tag with a release commit", t.Name().Short())
+		}
+
+		if ok {
+			previous = t
+			version = ref.Version
+		}
+
+		return nil
+	})
+
+	return previous, err
+}
+
+// resolveTag returns a commit of either lightweight or annotated tag
+func resolveTag(repo *git.Repository, ref *plumbing.Reference) (*object.Commit, error) {
+	if t, err := repo.TagObject(ref.Hash()); err == nil {
+		c, err := t.Commit()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving commit of %v tag", ref.Name().Short())
+		}
+
+		return c, nil
+	}
+
+	c, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving commit of %v tag", ref.Name().Short())
+	}
+
+	return c, nil
+}
+
+// ConventionalChangelog renders Conventional Commits as markdown sections
+func ConventionalChangelog(commits []*ConventionalCommit, repository string) string {
+	var o []string
+
+	breaking := make([]string, 0)
+	for _, c := range commits {
+		if c.Breaking != "" {
+			breaking = append(breaking, conventionalEntry(c, c.Breaking, repository))
+		}
+	}
+	if len(breaking) != 0 {
+		o = append(o, "### Breaking Changes\n", fmt.Sprintf("%v\n", strings.Join(breaking, "\n")))
+	}
+
+	for _, s := range conventionalSections {
+		entries := make([]string, 0)
+		for _, c := range commits {
+			if c.Type == s.Type {
+				entries = append(entries, conventionalEntry(c, c.Subject, repository))
+			}
+		}
+
+		if len(entries) != 0 {
+			o = append(o, fmt.Sprintf("### %v\n", s.Title), fmt.Sprintf("%v\n", strings.Join(entries, "\n")))
+		}
+	}
+
+	return strings.Join(o, "\n")
+}
+
+func conventionalEntry(c *ConventionalCommit, text, repository string) string {
+	text = regexp.MustCompile(IssueRegex).ReplaceAllString(text, fmt.Sprintf("${1}[#${2}](%v/issues/${2})", repository))
+
+	if c.Scope != "" {
+		text = fmt.Sprintf("**%v:** %v", c.Scope, text)
+	}
+
+	if c.Hash == "" {
+		return fmt.Sprintf("- %v", text)
+	}
+
+	short := c.Hash
+	if len(short) > 7 {
+		short = short[:7]
+	}
+
+	return fmt.Sprintf("- %v ([%v](%v/commit/%v))", text, short, repository, c.Hash)
+}
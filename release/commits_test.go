@@ -0,0 +1,242 @@
+package release_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git-release/release"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Message  string
+		Expected *release.ConventionalCommit
+	}
+
+	suite := map[string]test{
+		"Feature": {
+			Message: "feat: add feature",
+			Expected: &release.ConventionalCommit{
+				Hash:    "111",
+				Type:    "feat",
+				Subject: "add feature",
+			},
+		},
+		"Fix with Scope": {
+			Message: "fix(parser): handle empty lines\n\nCloses #12",
+			Expected: &release.ConventionalCommit{
+				Hash:    "111",
+				Type:    "fix",
+				Scope:   "parser",
+				Subject: "handle empty lines",
+			},
+		},
+		"Breaking Marker": {
+			Message: "feat(api)!: drop v1 endpoints",
+			Expected: &release.ConventionalCommit{
+				Hash:     "111",
+				Type:     "feat",
+				Scope:    "api",
+				Subject:  "drop v1 endpoints",
+				Breaking: "drop v1 endpoints",
+			},
+		},
+		"Breaking Footer": {
+			Message: "refactor: rename config\n\nBREAKING CHANGE: `TOKEN` was renamed to `GITHUB_TOKEN`",
+			Expected: &release.ConventionalCommit{
+				Hash:     "111",
+				Type:     "refactor",
+				Subject:  "rename config",
+				Breaking: "`TOKEN` was renamed to `GITHUB_TOKEN`",
+			},
+		},
+		"Not Conventional": {
+			Message:  "Update README.md",
+			Expected: nil,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a.Equal(test.Expected, release.ParseConventionalCommit("111", test.Message))
+	}
+}
+
+func TestConventionalChangelog(t *testing.T) {
+	a := assert.New(t)
+
+	commits := []*release.ConventionalCommit{
+		{
+			Hash:    "1111111111",
+			Type:    "fix",
+			Subject: "crash on empty input (#34)",
+		},
+		{
+			Hash:     "2222222222",
+			Type:     "feat",
+			Scope:    "api",
+			Subject:  "drop v1 endpoints",
+			Breaking: "drop v1 endpoints",
+		},
+		{
+			Hash:    "3333333333",
+			Type:    "chore",
+			Subject: "update dependencies",
+		},
+		{
+			Hash:    "4444444444",
+			Type:    "feat",
+			Subject: "add feature",
+		},
+	}
+
+	expected := `### Breaking Changes
+
+- **api:** drop v1 endpoints ([2222222](https://github.com/owner/repo/commit/2222222222))
+
+### Features
+
+- **api:** drop v1 endpoints ([2222222](https://github.com/owner/repo/commit/2222222222))
+- add feature ([4444444](https://github.com/owner/repo/commit/4444444444))
+
+### Bug Fixes
+
+- crash on empty input ([#34](https://github.com/owner/repo/issues/34)) ([1111111](https://github.com/owner/repo/commit/1111111111))
+`
+
+	a.Equal(expected, release.ConventionalChangelog(commits, "https://github.com/owner/repo"))
+	a.Equal("", release.ConventionalChangelog([]*release.ConventionalCommit{}, "https://github.com/owner/repo"))
+}
+
+func TestCommitsChangelog(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("error preparing test case: error initializing repository: %v", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("error preparing test case: error opening worktree: %v", err)
+	}
+
+	commit := func(message string) plumbing.Hash {
+		if err := os.WriteFile(filepath.Join(dir, "file"), []byte(message), 0644); err != nil {
+			t.Fatalf("error preparing test case: error writing file: %v", err)
+		}
+
+		if _, err := wt.Add("file"); err != nil {
+			t.Fatalf("error preparing test case: error staging file: %v", err)
+		}
+
+		h, err := wt.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatalf("error preparing test case: error committing: %v", err)
+		}
+
+		return h
+	}
+
+	tag := func(name string, h plumbing.Hash) {
+		if _, err := repo.CreateTag(name, h, nil); err != nil {
+			t.Fatalf("error preparing test case: error creating tag %v: %v", name, err)
+		}
+	}
+
+	initial := commit("feat: initial feature")
+	tag("v1.0.0", initial)
+	tag("api/v1.0.0", initial)
+	tag("latest", commit("fix: released bug"))
+	released := commit("feat: released feature")
+	tag("v1.1.0", released)
+	tag("web-v1.2.0", released)
+	commit("Update README.md")
+	commit("fix: unreleased bug")
+	head := commit("feat: unreleased feature")
+	tag("v2.0.0", head)
+
+	type test struct {
+		Reference *release.Reference
+	}
+
+	suite := map[string]test{
+		"Since Previous Tag": {
+			Reference: &release.Reference{
+				CommitHash: head.String(),
+				Tag:        "v2.0.0",
+				Version:    "2.0.0",
+			},
+		},
+		"Unreleased": {
+			Reference: &release.Reference{
+				CommitHash: head.String(),
+				Tag:        "latest",
+				Version:    "Unreleased",
+			},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{Reference: test.Reference}
+
+		changelog, err := rel.CommitsChangelog(dir, "", "https://github.com/owner/repo")
+		a.Equal(nil, err)
+		a.Contains(changelog, "- unreleased feature")
+		a.Contains(changelog, "- unreleased bug")
+		a.NotContains(changelog, "- released feature")
+		a.NotContains(changelog, "- released bug")
+		a.NotContains(changelog, "README")
+	}
+
+	rel := &release.Release{
+		Reference: &release.Reference{
+			CommitHash: head.String(),
+			Tag:        "v1.0.0",
+			Version:    "1.0.0",
+		},
+	}
+
+	changelog, err := rel.CommitsChangelog(dir, "", "https://github.com/owner/repo")
+	a.Equal(nil, err)
+	a.Contains(changelog, "- initial feature")
+	a.Contains(changelog, "- unreleased feature")
+
+	// tags of other components are ignored
+	rel = &release.Release{
+		Reference: &release.Reference{
+			CommitHash: head.String(),
+			Tag:        "api/v2.0.0",
+			Version:    "2.0.0",
+			Prefix:     "api/v",
+		},
+	}
+
+	changelog, err = rel.CommitsChangelog(dir, components[0].PrefixRegex(), "https://github.com/owner/repo")
+	a.Equal(nil, err)
+	a.Contains(changelog, "- released feature")
+	a.Contains(changelog, "- unreleased feature")
+	a.NotContains(changelog, "- initial feature")
+}
@@ -0,0 +1,94 @@
+package release
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// Component is a separately released part of a monorepo, selected by a tag prefix
+type Component struct {
+	Name        string   `yaml:"name"`
+	Prefix      string   `yaml:"prefix"`
+	Changelog   string   `yaml:"changelog"`
+	ReleaseName string   `yaml:"release_name"`
+	Assets      []string `yaml:"assets"`
+}
+
+// ComponentsPrefixRegex returns a tag prefix regex matching prefixes of all components
+func ComponentsPrefixRegex(components []Component) string {
+	prefixes := make([]string, 0)
+	for _, c := range components {
+		prefixes = append(prefixes, regexp.QuoteMeta(c.Prefix))
+	}
+
+	// longer prefixes first, so that 'api-v2/' is preferred over 'api-'
+	sort.SliceStable(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	return fmt.Sprintf("(?:%v)[v]?", strings.Join(prefixes, "|"))
+}
+
+// PrefixRegex returns a tag prefix regex matching tags of the component only
+func (c *Component) PrefixRegex() string {
+	return fmt.Sprintf("%v[v]?", regexp.QuoteMeta(c.Prefix))
+}
+
+// FindComponent returns a component with the longest prefix matching a tag 'prefix'
+func FindComponent(components []Component, prefix string) (*Component, error) {
+	var component *Component
+	for i, c := range components {
+		if !strings.HasPrefix(prefix, c.Prefix) {
+			continue
+		}
+
+		if component == nil || len(c.Prefix) > len(component.Prefix) {
+			component = &components[i]
+		}
+	}
+
+	if component == nil {
+		return nil, errors.New(fmt.Sprintf("no component matches tag prefix '%v'", prefix))
+	}
+
+	return component, nil
+}
+
+// Apply overrides release name and assets with component configuration
+func (c *Component) Apply(fs afero.Fs, r *Release) error {
+	if c.ReleaseName != "" {
+		t, err := template.New(c.Name).Parse(c.ReleaseName)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing release name template of component %v", c.Name)
+		}
+
+		var name bytes.Buffer
+		err = t.Execute(&name, map[string]string{
+			"Component": c.Name,
+			"Tag":       r.Reference.Tag,
+			"Version":   r.Reference.Version,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error rendering release name of component %v", c.Name)
+		}
+
+		r.Name = name.String()
+	}
+
+	if len(c.Assets) != 0 {
+		var err error
+		r.Assets, err = GetAssets(fs, c.Assets)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving release assets of component %v", c.Name)
+		}
+	}
+
+	return nil
+}
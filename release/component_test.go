@@ -0,0 +1,176 @@
+package release_test
+
+import (
+	"os"
+	"testing"
+
+	"git-release/release"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+var components = []release.Component{
+	{
+		Name:        "api",
+		Prefix:      "api/",
+		Changelog:   "services/api/CHANGELOG.md",
+		ReleaseName: "API {{ .Version }}",
+		Assets:      []string{"services/api/build/*.zip"},
+	},
+	{
+		Name:   "api-v2",
+		Prefix: "api/v2/",
+	},
+	{
+		Name:   "web",
+		Prefix: "web-",
+	},
+}
+
+func TestComponentsPrefix(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		GitHubRef         string
+		ExpectedPrefix    string
+		ExpectedVersion   string
+		ExpectedComponent string
+		ExpectedError     string
+	}
+
+	suite := map[string]test{
+		"Component": {
+			GitHubRef:         "refs/tags/api/v1.4.0",
+			ExpectedPrefix:    "api/v",
+			ExpectedVersion:   "1.4.0",
+			ExpectedComponent: "api",
+		},
+		"Component with Longer Prefix": {
+			GitHubRef:         "refs/tags/api/v2/1.0.0",
+			ExpectedPrefix:    "api/v2/",
+			ExpectedVersion:   "1.0.0",
+			ExpectedComponent: "api-v2",
+		},
+		"Component without 'v' Prefix": {
+			GitHubRef:         "refs/tags/web-2.0.0",
+			ExpectedPrefix:    "web-",
+			ExpectedVersion:   "2.0.0",
+			ExpectedComponent: "web",
+		},
+		"Unknown Component": {
+			GitHubRef:     "refs/tags/cli/v1.0.0",
+			ExpectedError: "error retrieving source code reference",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		os.Setenv("GITHUB_REF", test.GitHubRef)
+		os.Setenv("GITHUB_SHA", "111")
+		os.Setenv("GITHUB_REPOSITORY", "anton-yurchenko/git-release")
+
+		rel, err := release.GetRelease(afero.NewMemMapFs(), []string{}, release.ComponentsPrefixRegex(components), release.SemVer{}, "", "", "", "", false)
+		if test.ExpectedError != "" || err != nil {
+			a.ErrorContains(err, test.ExpectedError)
+			continue
+		}
+
+		a.Equal(test.ExpectedPrefix, rel.Reference.Prefix)
+		a.Equal(test.ExpectedVersion, rel.Reference.Version)
+
+		c, err := release.FindComponent(components, rel.Reference.Prefix)
+		a.Equal(nil, err)
+		a.Equal(test.ExpectedComponent, c.Name)
+	}
+
+	os.Unsetenv("GITHUB_REF")
+	os.Unsetenv("GITHUB_SHA")
+	os.Unsetenv("GITHUB_REPOSITORY")
+}
+
+func TestFindComponent(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := release.FindComponent(components, "")
+	a.EqualError(err, "no component matches tag prefix ''")
+
+	c, err := release.FindComponent(append(components, release.Component{Name: "default"}), "")
+	a.Equal(nil, err)
+	a.Equal("default", c.Name)
+}
+
+func TestComponentApply(t *testing.T) {
+	a := assert.New(t)
+
+	fs := afero.NewMemMapFs()
+	for _, f := range []string{"services/api/build/linux.zip", "services/api/build/darwin.zip", "services/web/build/web.zip"} {
+		if err := afero.WriteFile(fs, f, []byte("content"), 0644); err != nil {
+			t.Fatalf("error preparing test case: error creating file %v: %v", f, err)
+		}
+	}
+
+	type test struct {
+		Component      release.Component
+		ExpectedName   string
+		ExpectedAssets *[]release.Asset
+		ExpectedError  string
+	}
+
+	suite := map[string]test{
+		"Name and Assets": {
+			Component:    components[0],
+			ExpectedName: "API 1.4.0",
+			ExpectedAssets: &[]release.Asset{
+				{
+					Name: "darwin.zip",
+					Path: "services/api/build/darwin.zip",
+				},
+				{
+					Name: "linux.zip",
+					Path: "services/api/build/linux.zip",
+				},
+			},
+		},
+		"Defaults": {
+			Component:      components[2],
+			ExpectedName:   "api/v1.4.0",
+			ExpectedAssets: &[]release.Asset{},
+		},
+		"Malformed Template": {
+			Component: release.Component{
+				Name:        "api",
+				ReleaseName: "{{ .Version",
+			},
+			ExpectedError: "error parsing release name template of component api",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Name: "api/v1.4.0",
+			Reference: &release.Reference{
+				Tag:     "api/v1.4.0",
+				Version: "1.4.0",
+				Prefix:  "api/v",
+			},
+			Assets: &[]release.Asset{},
+		}
+
+		err := test.Component.Apply(fs, rel)
+		if test.ExpectedError != "" || err != nil {
+			a.ErrorContains(err, test.ExpectedError)
+			continue
+		}
+
+		a.Equal(test.ExpectedName, rel.Name)
+		a.Equal(test.ExpectedAssets, rel.Assets)
+	}
+}
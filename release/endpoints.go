@@ -0,0 +1,63 @@
+package release
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	GitHubAPIURL    string = "https://api.github.com"
+	GitHubServerURL string = "https://github.com"
+	GitHubUploadURL string = "https://uploads.github.com"
+)
+
+// Endpoints are base URLs of GitHub API and assets uploads
+type Endpoints struct {
+	API        string
+	Upload     string
+	Enterprise bool
+}
+
+// GetEndpoints resolves API and uploads URLs of github.com, GitHub Enterprise Server ('https://<host>/api/v3')
+// or GitHub Enterprise Cloud with data residency ('https://api.<tenant>.ghe.com').
+// Detected uploads URL is overridden by 'uploadURL' unless it is empty.
+func GetEndpoints(apiURL, serverURL, uploadURL string) (*Endpoints, error) {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	serverURL = strings.TrimSuffix(serverURL, "/")
+	if apiURL == "" {
+		apiURL = GitHubAPIURL
+	}
+
+	e := &Endpoints{
+		API:        apiURL,
+		Upload:     GitHubUploadURL,
+		Enterprise: apiURL != GitHubAPIURL || (serverURL != "" && serverURL != GitHubServerURL),
+	}
+
+	if e.Enterprise {
+		api, err := url.Parse(apiURL)
+		if err != nil || api.Scheme == "" || api.Host == "" {
+			return nil, errors.New(fmt.Sprintf("malformed GitHub API URL '%v'", apiURL))
+		}
+
+		switch {
+		case strings.HasPrefix(api.Host, "api.") && strings.HasSuffix(api.Host, ".ghe.com"):
+			e.Upload = fmt.Sprintf("%v://uploads.%v", api.Scheme, strings.TrimPrefix(api.Host, "api."))
+		case strings.HasSuffix(api.Path, "/api/v3"):
+			e.Upload = fmt.Sprintf("%v://%v%v/api/uploads", api.Scheme, api.Host, strings.TrimSuffix(api.Path, "/api/v3"))
+		case serverURL != "":
+			e.Upload = fmt.Sprintf("%v/api/uploads", serverURL)
+		default:
+			e.Upload = fmt.Sprintf("%v://%v/api/uploads", api.Scheme, api.Host)
+		}
+	}
+
+	if uploadURL != "" {
+		e.Upload = strings.TrimSuffix(uploadURL, "/")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,105 @@
+package release_test
+
+import (
+	"testing"
+
+	"git-release/release"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEndpoints(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		APIURL        string
+		ServerURL     string
+		UploadURL     string
+		Expected      *release.Endpoints
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"GitHub": {
+			APIURL:    "https://api.github.com",
+			ServerURL: "https://github.com",
+			Expected: &release.Endpoints{
+				API:    "https://api.github.com",
+				Upload: "https://uploads.github.com",
+			},
+		},
+		"GitHub Enterprise Server": {
+			APIURL:    "https://github.example.com/api/v3",
+			ServerURL: "https://github.example.com",
+			Expected: &release.Endpoints{
+				API:        "https://github.example.com/api/v3",
+				Upload:     "https://github.example.com/api/uploads",
+				Enterprise: true,
+			},
+		},
+		"GitHub Enterprise Server with Trailing Slash": {
+			APIURL:    "https://github.example.com/api/v3/",
+			ServerURL: "https://github.example.com/",
+			Expected: &release.Endpoints{
+				API:        "https://github.example.com/api/v3",
+				Upload:     "https://github.example.com/api/uploads",
+				Enterprise: true,
+			},
+		},
+		"GitHub Enterprise Cloud with Data Residency": {
+			APIURL:    "https://api.octocorp.ghe.com",
+			ServerURL: "https://octocorp.ghe.com",
+			Expected: &release.Endpoints{
+				API:        "https://api.octocorp.ghe.com",
+				Upload:     "https://uploads.octocorp.ghe.com",
+				Enterprise: true,
+			},
+		},
+		"Custom API URL only": {
+			APIURL:    "https://github.example.com/api/v3",
+			ServerURL: "https://github.com",
+			Expected: &release.Endpoints{
+				API:        "https://github.example.com/api/v3",
+				Upload:     "https://github.example.com/api/uploads",
+				Enterprise: true,
+			},
+		},
+		"Custom Server URL only": {
+			APIURL:    "https://api.github.com",
+			ServerURL: "https://github.example.com",
+			Expected: &release.Endpoints{
+				API:        "https://api.github.com",
+				Upload:     "https://github.example.com/api/uploads",
+				Enterprise: true,
+			},
+		},
+		"Upload URL Override": {
+			APIURL:    "https://github.example.com/api/v3",
+			ServerURL: "https://github.example.com",
+			UploadURL: "https://uploads.example.com/",
+			Expected: &release.Endpoints{
+				API:        "https://github.example.com/api/v3",
+				Upload:     "https://uploads.example.com",
+				Enterprise: true,
+			},
+		},
+		"Malformed API URL": {
+			APIURL:        "github.example.com",
+			ServerURL:     "https://github.example.com",
+			ExpectedError: "malformed GitHub API URL 'github.example.com'",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// test
+		e, err := release.GetEndpoints(test.APIURL, test.ServerURL, test.UploadURL)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, e)
+	}
+}
@@ -0,0 +1,79 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// EventRelease is a GITHUB_EVENT_NAME of a workflow triggered by a release event
+const EventRelease string = "release"
+
+// GetReleaseEvent returns a release that triggered a workflow, as described by GITHUB_EVENT_PATH payload
+func GetReleaseEvent(fs afero.Fs) (*github.RepositoryRelease, error) {
+	if os.Getenv("GITHUB_EVENT_PATH") == "" {
+		return nil, errors.New("GITHUB_EVENT_PATH is not defined")
+	}
+
+	b, err := afero.ReadFile(fs, os.Getenv("GITHUB_EVENT_PATH"))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading event payload")
+	}
+
+	event := new(github.ReleaseEvent)
+	if err := json.Unmarshal(b, event); err != nil {
+		return nil, errors.Wrap(err, "error parsing event payload")
+	}
+
+	if event.GetRelease().GetID() == 0 {
+		return nil, errors.New("event payload does not contain a release")
+	}
+
+	if event.GetAction() == "deleted" {
+		return nil, errors.New(fmt.Sprintf("release %v was deleted", event.GetRelease().GetTagName()))
+	}
+
+	return event.GetRelease(), nil
+}
+
+// Attach uploads assets to an existing 'event' release instead of creating one.
+// An empty release body is filled with a changelog when 'fillBody' is set.
+func (r *Release) Attach(fs afero.Fs, cli RepositoriesClient, httpCli *http.Client, api APIClient, event *github.RepositoryRelease, fillBody bool) error {
+	if event.GetTagName() != r.Reference.Tag {
+		return errors.New(fmt.Sprintf("release event tag %v does not match GITHUB_REF tag %v", event.GetTagName(), r.Reference.Tag))
+	}
+
+	r.ID = event.GetID()
+
+	if fillBody && event.GetBody() == "" && r.Changelog != "" {
+		_, _, err := cli.EditRelease(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			r.ID,
+			&github.RepositoryRelease{
+				Body: &r.Changelog,
+			},
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error updating %v release body", r.Reference.Tag)
+		}
+
+		log.Info("release body filled from changelog")
+	}
+
+	// event payload does not reflect assets uploaded by a previous attempt
+	uploaded, err := r.ListAssets(cli)
+	if err != nil {
+		return err
+	}
+
+	return r.UploadAssets(fs, cli, httpCli, api, r.ID, uploaded)
+}
@@ -0,0 +1,181 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReleaseEvent(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		EventPath     string
+		Payload       string
+		Expected      *github.RepositoryRelease
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"Published": {
+			EventPath: "/github/workflow/event.json",
+			Payload:   `{"action":"published","release":{"id":1,"tag_name":"v1.0.0","body":"changes"}}`,
+			Expected: &github.RepositoryRelease{
+				ID:      int64P(1),
+				TagName: stringP("v1.0.0"),
+				Body:    stringP("changes"),
+			},
+		},
+		"Deleted": {
+			EventPath:     "/github/workflow/event.json",
+			Payload:       `{"action":"deleted","release":{"id":1,"tag_name":"v1.0.0"}}`,
+			ExpectedError: "release v1.0.0 was deleted",
+		},
+		"Not a Release Event": {
+			EventPath:     "/github/workflow/event.json",
+			Payload:       `{"ref":"refs/heads/master"}`,
+			ExpectedError: "event payload does not contain a release",
+		},
+		"Malformed Payload": {
+			EventPath:     "/github/workflow/event.json",
+			Payload:       `{`,
+			ExpectedError: "error parsing event payload: unexpected end of JSON input",
+		},
+		"Missing Payload": {
+			EventPath:     "/github/workflow/missing.json",
+			ExpectedError: "error reading event payload: open /github/workflow/missing.json: file does not exist",
+		},
+		"Undefined Event Path": {
+			ExpectedError: "GITHUB_EVENT_PATH is not defined",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		fs := afero.NewMemMapFs()
+		if test.Payload != "" {
+			if err := afero.WriteFile(fs, test.EventPath, []byte(test.Payload), 0644); err != nil {
+				t.Fatalf("error preparing test case: error creating file: %v", err)
+			}
+		}
+
+		if err := os.Setenv("GITHUB_EVENT_PATH", test.EventPath); err != nil {
+			t.Fatalf("error preparing test case: error setting environmental variable GITHUB_EVENT_PATH=%v: %v", test.EventPath, err)
+		}
+
+		// test
+		event, err := release.GetReleaseEvent(fs)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, event)
+
+		// cleanup
+		if err := os.Unsetenv("GITHUB_EVENT_PATH"); err != nil {
+			t.Fatalf("error cleanup: error unsetting environmental variable GITHUB_EVENT_PATH: %v", err)
+		}
+	}
+}
+
+func TestAttach(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	type test struct {
+		Event            *github.RepositoryRelease
+		FillBody         bool
+		EditReleaseError error
+		ExpectedEdit     bool
+		ExpectedList     bool
+		ExpectedError    string
+	}
+
+	suite := map[string]test{
+		"Attach": {
+			Event:        &github.RepositoryRelease{ID: int64P(1), TagName: stringP("v1.0.0"), Body: stringP("")},
+			ExpectedList: true,
+		},
+		"Fill Empty Body": {
+			Event:        &github.RepositoryRelease{ID: int64P(1), TagName: stringP("v1.0.0"), Body: stringP("")},
+			FillBody:     true,
+			ExpectedEdit: true,
+			ExpectedList: true,
+		},
+		"Keep Existing Body": {
+			Event:        &github.RepositoryRelease{ID: int64P(1), TagName: stringP("v1.0.0"), Body: stringP("handwritten")},
+			FillBody:     true,
+			ExpectedList: true,
+		},
+		"Edit Error": {
+			Event:            &github.RepositoryRelease{ID: int64P(1), TagName: stringP("v1.0.0")},
+			FillBody:         true,
+			EditReleaseError: errors.New("reason"),
+			ExpectedEdit:     true,
+			ExpectedError:    "error updating v1.0.0 release body: reason",
+		},
+		"Tag Mismatch": {
+			Event:         &github.RepositoryRelease{ID: int64P(1), TagName: stringP("v0.9.0")},
+			ExpectedError: "release event tag v0.9.0 does not match GITHUB_REF tag v1.0.0",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.0.0",
+				Version:    "1.0.0",
+			},
+			Changelog: "changelog",
+		}
+
+		// test
+		m := new(mocks.RepositoriesClient)
+
+		if test.ExpectedEdit {
+			m.On("EditRelease",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(1),
+				&github.RepositoryRelease{Body: stringP("changelog")}).Return(nil, nil, test.EditReleaseError).Once()
+		}
+
+		if test.ExpectedList {
+			m.On("ListReleaseAssets",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(1),
+				&github.ListOptions{PerPage: 100}).Return([]*github.ReleaseAsset{}, &github.Response{}, nil).Once()
+		}
+
+		err := rel.Attach(afero.NewMemMapFs(), m, nil, nil, test.Event, test.FillBody)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+	}
+}
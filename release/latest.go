@@ -0,0 +1,116 @@
+package release
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	MakeLatestTrue   string = "true"
+	MakeLatestFalse  string = "false"
+	MakeLatestLegacy string = "legacy"
+	MakeLatestAuto   string = "auto"
+)
+
+type releaseRequest struct {
+	*github.RepositoryRelease
+	MakeLatest string `json:"make_latest,omitempty"`
+}
+
+// LatestRelease returns a tag and a version of the highest published stable release other than the current one
+func (r *Release) LatestRelease(cli RepositoriesClient, prefix string) (string, string, error) {
+	releases, err := r.ListReleases(cli)
+	if err != nil {
+		return "", "", err
+	}
+
+	var latest, version string
+	for _, rel := range releases {
+		if rel.GetDraft() || rel.GetPrerelease() || rel.GetTagName() == r.Reference.Tag {
+			continue
+		}
+
+		ref, err := ParseTag(rel.GetTagName(), prefix, r.versionScheme())
+		if err != nil {
+			continue
+		}
+
+		if version == "" || r.versionScheme().Compare(ref.Version, version) > 0 {
+			latest = rel.GetTagName()
+			version = ref.Version
+		}
+	}
+
+	return latest, version, nil
+}
+
+// ResolveMakeLatest returns a 'make_latest' value of a release, 'auto' is resolved to 'true'
+// only when the version exceeds versions of all other published stable releases
+func (r *Release) ResolveMakeLatest(cli RepositoriesClient, mode, prefix string) (string, error) {
+	if mode != MakeLatestAuto {
+		return mode, nil
+	}
+
+	if r.Draft || r.PreRelease || r.Reference.Version == "Unreleased" {
+		return MakeLatestFalse, nil
+	}
+
+	latest, version, err := r.LatestRelease(cli, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	if version != "" && r.versionScheme().Compare(r.Reference.Version, version) <= 0 {
+		log.Infof("release will not be marked as latest, %v is the latest release", latest)
+		return MakeLatestFalse, nil
+	}
+
+	return MakeLatestTrue, nil
+}
+
+// createRelease creates a release passing 'make_latest' setting that is not supported by the client library
+func (r *Release) createRelease(api APIClient, rel *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	req, err := api.NewRequest(
+		"POST",
+		fmt.Sprintf("repos/%v/%v/releases", r.Slug.Owner, r.Slug.Name),
+		&releaseRequest{
+			RepositoryRelease: rel,
+			MakeLatest:        r.MakeLatest,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error preparing release request")
+	}
+
+	o := new(github.RepositoryRelease)
+	if _, err := api.Do(context.Background(), req, o); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// updateLatest sets 'make_latest' setting of an existing release
+func (r *Release) updateLatest(api APIClient, id int64) error {
+	req, err := api.NewRequest(
+		"PATCH",
+		fmt.Sprintf("repos/%v/%v/releases/%v", r.Slug.Owner, r.Slug.Name, id),
+		&releaseRequest{
+			RepositoryRelease: new(github.RepositoryRelease),
+			MakeLatest:        r.MakeLatest,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "error preparing release request")
+	}
+
+	if _, err := api.Do(context.Background(), req, nil); err != nil {
+		return errors.Wrapf(err, "error updating latest release setting of %v release", r.Reference.Tag)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolveMakeLatest(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	releases := []*github.RepositoryRelease{
+		{TagName: stringP("v3.0.0-rc.1"), Prerelease: boolP(true)},
+		{TagName: stringP("v2.1.0")},
+		{TagName: stringP("v1.8.4")},
+		{TagName: stringP("web-v9.0.0")},
+		{TagName: stringP("api/v2.0.0")},
+	}
+
+	type test struct {
+		Tag              string
+		Prefix           string
+		Mode             string
+		Version          string
+		PreRelease       bool
+		ExpectedListCall bool
+		Expected         string
+	}
+
+	suite := map[string]test{
+		"Explicit": {
+			Mode:     release.MakeLatestLegacy,
+			Version:  "1.8.5",
+			Expected: release.MakeLatestLegacy,
+		},
+		"Auto Backport": {
+			Mode:             release.MakeLatestAuto,
+			Version:          "1.8.5",
+			ExpectedListCall: true,
+			Expected:         release.MakeLatestFalse,
+		},
+		"Auto Newer Version": {
+			Mode:             release.MakeLatestAuto,
+			Version:          "2.2.0",
+			ExpectedListCall: true,
+			Expected:         release.MakeLatestTrue,
+		},
+		"Auto Component": {
+			Tag:              "api/v2.1.0",
+			Prefix:           components[0].PrefixRegex(),
+			Mode:             release.MakeLatestAuto,
+			Version:          "2.1.0",
+			ExpectedListCall: true,
+			Expected:         release.MakeLatestTrue,
+		},
+		"Auto Pre-Release": {
+			Mode:       release.MakeLatestAuto,
+			Version:    "3.0.0-rc.2",
+			PreRelease: true,
+			Expected:   release.MakeLatestFalse,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		tag := test.Tag
+		if tag == "" {
+			tag = "v" + test.Version
+		}
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				Tag:     tag,
+				Version: test.Version,
+			},
+			PreRelease: test.PreRelease,
+		}
+
+		// test
+		m := new(mocks.RepositoriesClient)
+
+		if test.ExpectedListCall {
+			m.On("ListReleases",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.ListOptions{PerPage: 100}).Return(releases, &github.Response{}, nil).Once()
+		}
+
+		latest, err := rel.ResolveMakeLatest(m, test.Mode, test.Prefix)
+		a.Equal(nil, err)
+		a.Equal(test.Expected, latest)
+		m.AssertExpectations(t)
+	}
+}
+
+func TestPublishMakeLatest(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	type test struct {
+		UpdateExisting    bool
+		CreateMockError   error
+		ExpectedMethod    string
+		ExpectedURL       string
+		ExpectedBody      map[string]interface{}
+		ExpectedError     string
+		ExpectedReleaseID int64
+	}
+
+	suite := map[string]test{
+		"Create": {
+			ExpectedMethod: "POST",
+			ExpectedURL:    "repos/anton-yurchenko/git-release/releases",
+			ExpectedBody: map[string]interface{}{
+				"name":             "v1.8.5",
+				"tag_name":         "v1.8.5",
+				"target_commitish": "111",
+				"body":             "changelog",
+				"draft":            false,
+				"prerelease":       false,
+				"make_latest":      "false",
+			},
+			ExpectedReleaseID: 1,
+		},
+		"Update Existing": {
+			UpdateExisting:  true,
+			CreateMockError: errors.New("422 Validation Failed [{Resource:Release Field:tag_name Code:already_exists Message:}]"),
+			ExpectedMethod:  "PATCH",
+			ExpectedURL:     "repos/anton-yurchenko/git-release/releases/2",
+			ExpectedBody: map[string]interface{}{
+				"make_latest": "false",
+			},
+			ExpectedReleaseID: 2,
+		},
+		"Create Error": {
+			CreateMockError: errors.New("reason"),
+			ExpectedError:   "reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Name: "v1.8.5",
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.8.5",
+				Version:    "1.8.5",
+			},
+			Changelog:      "changelog",
+			MakeLatest:     release.MakeLatestFalse,
+			UpdateExisting: test.UpdateExisting,
+		}
+
+		// test
+		repoMock := new(mocks.RepositoriesClient)
+		apiMock := new(mocks.APIClient)
+
+		body := func(expected map[string]interface{}) interface{} {
+			return mock.MatchedBy(func(body interface{}) bool {
+				b, err := json.Marshal(body)
+				if err != nil {
+					return false
+				}
+
+				m := make(map[string]interface{})
+				if err := json.Unmarshal(b, &m); err != nil {
+					return false
+				}
+
+				return assert.ObjectsAreEqual(expected, m)
+			})
+		}
+
+		var createBody interface{} = mock.Anything
+		if !test.UpdateExisting && test.ExpectedBody != nil {
+			createBody = body(test.ExpectedBody)
+		}
+
+		createReq := new(http.Request)
+		apiMock.On("NewRequest",
+			"POST",
+			"repos/anton-yurchenko/git-release/releases",
+			createBody).Return(createReq, nil).Once()
+
+		apiMock.On("Do",
+			context.Background(),
+			createReq,
+			mock.Anything).Run(func(args mock.Arguments) {
+			_ = json.Unmarshal([]byte(`{"id":1}`), args.Get(2))
+		}).Return(nil, test.CreateMockError).Once()
+
+		if test.UpdateExisting {
+			repoMock.On("GetReleaseByTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				rel.Reference.Tag).Return(&github.RepositoryRelease{ID: int64P(2)}, nil, nil).Once()
+
+			updateReq := new(http.Request)
+			apiMock.On("NewRequest",
+				test.ExpectedMethod,
+				test.ExpectedURL,
+				body(test.ExpectedBody)).Return(updateReq, nil).Once()
+
+			apiMock.On("Do",
+				context.Background(),
+				updateReq,
+				nil).Return(nil, nil).Once()
+		}
+
+		err := rel.Publish(afero.NewMemMapFs(), repoMock, nil, apiMock)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		} else {
+			a.Equal(test.ExpectedReleaseID, rel.ID)
+		}
+		repoMock.AssertExpectations(t)
+		apiMock.AssertExpectations(t)
+	}
+}
@@ -3,7 +3,10 @@ package release
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/google/go-github/github"
 )
@@ -25,6 +28,21 @@ type Release struct {
 	PreRelease bool
 	Assets     *[]Asset
 	Changelog  string
+
+	// Date is a release date of the version in the changelog file
+	Date *time.Time
+
+	// ID is set once the release is published
+	ID int64
+
+	// MakeLatest is a 'make_latest' setting of the release (true, false or legacy), unset keeps GitHub default
+	MakeLatest string
+
+	// UpdateExisting allows publishing into an already existing release with the same tag
+	UpdateExisting bool
+
+	// Scheme is a version scheme of release tags, SemVer when unset
+	Scheme VersionScheme
 }
 
 type Slug struct {
@@ -36,23 +54,53 @@ type Reference struct {
 	CommitHash string
 	Tag        string
 	Version    string
+
+	// Prefix is a part of the tag matched by a custom tag prefix regex
+	Prefix string
 }
 
 type Asset struct {
 	Name string
 	Path string
+
+	// Generated is set for SBOMs and provenance attestations created by git-release
+	Generated bool
 }
 
 type RepositoriesClient interface {
 	UploadReleaseAsset(context.Context, string, string, int64, *github.UploadOptions, *os.File) (*github.ReleaseAsset, *github.Response, error)
 	CreateRelease(context.Context, string, string, *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error)
+	EditRelease(context.Context, string, string, int64, *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error)
 	DeleteRelease(context.Context, string, string, int64) (*github.Response, error)
 	GetReleaseByTag(context.Context, string, string, string) (*github.RepositoryRelease, *github.Response, error)
 	DeleteReleaseAsset(context.Context, string, string, int64) (*github.Response, error)
+	ListReleaseAssets(context.Context, string, string, int64, *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error)
+	DownloadReleaseAsset(context.Context, string, string, int64) (io.ReadCloser, string, error)
+	ListReleases(context.Context, string, string, *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
+	Get(context.Context, string, string) (*github.Repository, *github.Response, error)
+	CompareCommits(context.Context, string, string, string, string) (*github.CommitsComparison, *github.Response, error)
 }
 
 type GitClient interface {
 	CreateRef(context.Context, string, string, *github.Reference) (*github.Reference, *github.Response, error)
 	DeleteRef(context.Context, string, string, string) (*github.Response, error)
 	GetRef(context.Context, string, string, string) (*github.Reference, *github.Response, error)
+	GetTag(context.Context, string, string, string) (*github.Tag, *github.Response, error)
+	CreateTag(context.Context, string, string, *github.Tag) (*github.Tag, *github.Response, error)
+	UpdateRef(context.Context, string, string, *github.Reference, bool) (*github.Reference, *github.Response, error)
+	GetCommit(context.Context, string, string, string) (*github.Commit, *github.Response, error)
+	CreateCommit(context.Context, string, string, *github.Commit) (*github.Commit, *github.Response, error)
+	CreateBlob(context.Context, string, string, *github.Blob) (*github.Blob, *github.Response, error)
+	CreateTree(context.Context, string, string, string, []github.TreeEntry) (*github.Tree, *github.Response, error)
+}
+
+// RateLimitClient reports API rate limits of a token
+type RateLimitClient interface {
+	RateLimits(context.Context) (*github.RateLimits, *github.Response, error)
+}
+
+// APIClient allows calling GitHub API endpoints not covered by the client library
+type APIClient interface {
+	NewRequest(string, string, interface{}) (*http.Request, error)
+	Do(context.Context, *http.Request, interface{}) (*github.Response, error)
 }
@@ -0,0 +1,127 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	NightlyTagPrefix  string = "nightly-"
+	NightlyTagRegex   string = `^nightly-(?P<date>\d{8})(?:-(?P<number>\d+))?$`
+	nightlyDateFormat string = "20060102"
+)
+
+// NightlyTag returns the first available 'nightly-YYYYMMDD[-N]' tag of a day
+func (r *Release) NightlyTag(cli GitClient, today time.Time) (string, error) {
+	base := fmt.Sprintf("%v%v", NightlyTagPrefix, today.Format(nightlyDateFormat))
+
+	for i := 0; i < 100; i++ {
+		tag := base
+		if i > 0 {
+			tag = fmt.Sprintf("%v-%v", base, i)
+		}
+
+		_, _, err := cli.GetRef(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			fmt.Sprintf("refs/tags/%v", tag),
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), "404 Not Found") {
+				return tag, nil
+			}
+
+			return "", errors.Wrapf(err, "error fetching %v tag", tag)
+		}
+	}
+
+	return "", errors.New(fmt.Sprintf("too many nightly releases of %v", today.Format(nightlyDateFormat)))
+}
+
+type nightly struct {
+	id     int64
+	tag    string
+	date   time.Time
+	number int
+}
+
+// PruneNightlies deletes releases and tags of nightlies beyond the 'keep' most recent ones
+// or older than 'maxAge', zero values disable the corresponding limit. The current release is always kept.
+func (r *Release) PruneNightlies(repoCli RepositoriesClient, gitCli GitClient, keep int, maxAge time.Duration, now time.Time) error {
+	releases, err := r.ListReleases(repoCli)
+	if err != nil {
+		return err
+	}
+
+	regex := regexp.MustCompile(NightlyTagRegex)
+	nightlies := make([]nightly, 0)
+	for _, rel := range releases {
+		m := regex.FindStringSubmatch(rel.GetTagName())
+		if m == nil {
+			continue
+		}
+
+		date, err := time.Parse(nightlyDateFormat, m[regex.SubexpIndex("date")])
+		if err != nil {
+			continue
+		}
+
+		n, _ := strconv.Atoi(m[regex.SubexpIndex("number")])
+		nightlies = append(nightlies, nightly{
+			id:     rel.GetID(),
+			tag:    rel.GetTagName(),
+			date:   date,
+			number: n,
+		})
+	}
+
+	// most recent first
+	sort.SliceStable(nightlies, func(i, j int) bool {
+		if nightlies[i].date.Equal(nightlies[j].date) {
+			return nightlies[i].number > nightlies[j].number
+		}
+		return nightlies[i].date.After(nightlies[j].date)
+	})
+
+	for i, n := range nightlies {
+		if n.tag == r.Reference.Tag {
+			continue
+		}
+
+		if (keep <= 0 || i < keep) && (maxAge <= 0 || now.Truncate(24*time.Hour).Sub(n.date) <= maxAge) {
+			continue
+		}
+
+		log.Infof("pruning nightly release %v", n.tag)
+		_, err := repoCli.DeleteRelease(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			n.id,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error deleting nightly release %v", n.tag)
+		}
+
+		_, err = gitCli.DeleteRef(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			fmt.Sprintf("refs/tags/%v", n.tag),
+		)
+		if err != nil && !strings.Contains(err.Error(), "422 Reference does not exist") {
+			return errors.Wrapf(err, "error deleting nightly tag %v", n.tag)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNightlyTag(t *testing.T) {
+	a := assert.New(t)
+
+	today := time.Date(2024, 3, 5, 22, 0, 0, 0, time.UTC)
+
+	type test struct {
+		Existing      []string
+		GetRefError   error
+		Expected      string
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"First Nightly": {
+			Expected: "nightly-20240305",
+		},
+		"Second Nightly": {
+			Existing: []string{"nightly-20240305"},
+			Expected: "nightly-20240305-1",
+		},
+		"Third Nightly": {
+			Existing: []string{"nightly-20240305", "nightly-20240305-1"},
+			Expected: "nightly-20240305-2",
+		},
+		"Error": {
+			GetRefError:   errors.New("reason"),
+			ExpectedError: "error fetching nightly-20240305 tag: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "latest",
+				Version:    "Unreleased",
+			},
+		}
+
+		// test
+		m := new(mocks.GitClient)
+
+		for _, tag := range test.Existing {
+			m.On("GetRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"refs/tags/"+tag).Return(&github.Reference{}, nil, nil).Once()
+		}
+
+		getRefError := test.GetRefError
+		if getRefError == nil {
+			getRefError = errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release/git/refs/tags/nightly: 404 Not Found []")
+		}
+
+		if test.Expected != "" {
+			m.On("GetRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"refs/tags/"+test.Expected).Return(nil, nil, getRefError).Once()
+		} else {
+			m.On("GetRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"refs/tags/nightly-20240305").Return(nil, nil, getRefError).Once()
+		}
+
+		tag, err := rel.NightlyTag(m, today)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, tag)
+		m.AssertExpectations(t)
+	}
+}
+
+func TestPruneNightlies(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	now := time.Date(2024, 3, 20, 15, 0, 0, 0, time.UTC)
+
+	releases := []*github.RepositoryRelease{
+		{ID: int64P(1), TagName: stringP("nightly-20240320")},
+		{ID: int64P(2), TagName: stringP("nightly-20240319-1")},
+		{ID: int64P(3), TagName: stringP("nightly-20240319")},
+		{ID: int64P(4), TagName: stringP("v1.0.0")},
+		{ID: int64P(5), TagName: stringP("nightly-20240306")},
+		{ID: int64P(6), TagName: stringP("nightly-20240301")},
+	}
+
+	type test struct {
+		Keep               int
+		MaxAge             time.Duration
+		DeleteReleaseError error
+		ExpectedPruned     []int64
+		ExpectedError      string
+	}
+
+	suite := map[string]test{
+		"Keep": {
+			Keep:           2,
+			ExpectedPruned: []int64{3, 5, 6},
+		},
+		"Max Age": {
+			MaxAge:         14 * 24 * time.Hour,
+			ExpectedPruned: []int64{6},
+		},
+		"Keep and Max Age": {
+			Keep:           4,
+			MaxAge:         14 * 24 * time.Hour,
+			ExpectedPruned: []int64{6},
+		},
+		"Nothing to Prune": {
+			Keep:           10,
+			ExpectedPruned: []int64{},
+		},
+		"Delete Error": {
+			Keep:               4,
+			DeleteReleaseError: errors.New("reason"),
+			ExpectedPruned:     []int64{6},
+			ExpectedError:      "error deleting nightly release nightly-20240301: reason",
+		},
+	}
+
+	tags := map[int64]string{}
+	for _, rel := range releases {
+		tags[rel.GetID()] = rel.GetTagName()
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "nightly-20240320",
+				Version:    "Unreleased",
+			},
+		}
+
+		// test
+		repoMock := new(mocks.RepositoriesClient)
+		gitMock := new(mocks.GitClient)
+
+		repoMock.On("ListReleases",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name,
+			&github.ListOptions{PerPage: 100}).Return(releases, &github.Response{}, nil).Once()
+
+		for _, id := range test.ExpectedPruned {
+			repoMock.On("DeleteRelease",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				id).Return(nil, test.DeleteReleaseError).Once()
+
+			if test.DeleteReleaseError == nil {
+				gitMock.On("DeleteRef",
+					context.Background(),
+					rel.Slug.Owner,
+					rel.Slug.Name,
+					"refs/tags/"+tags[id]).Return(nil, nil).Once()
+			}
+		}
+
+		err := rel.PruneNightlies(repoMock, gitMock, test.Keep, test.MaxAge, now)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		repoMock.AssertExpectations(t)
+		gitMock.AssertExpectations(t)
+	}
+}
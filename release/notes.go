@@ -0,0 +1,129 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	GenerateNotesFallback string = "fallback"
+	GenerateNotesAppend   string = "append"
+	GenerateNotesPrepend  string = "prepend"
+	GenerateNotesOnly     string = "only"
+)
+
+type generateNotesRequest struct {
+	TagName               string `json:"tag_name"`
+	TargetCommitish       string `json:"target_commitish,omitempty"`
+	PreviousTagName       string `json:"previous_tag_name,omitempty"`
+	ConfigurationFilePath string `json:"configuration_file_path,omitempty"`
+}
+
+type generatedNotes struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// GenerateNotes returns release notes generated by GitHub for changes since the previous release.
+// 'config' is an optional path of release notes categories configuration file inside the repository.
+func (r *Release) GenerateNotes(api APIClient, cli RepositoriesClient, prefix, config string) (string, error) {
+	previous, err := r.PreviousRelease(cli, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	if previous != "" {
+		log.Infof("generating release notes since %v", previous)
+	}
+
+	req, err := api.NewRequest(
+		"POST",
+		fmt.Sprintf("repos/%v/%v/releases/generate-notes", r.Slug.Owner, r.Slug.Name),
+		&generateNotesRequest{
+			TagName:               r.Reference.Tag,
+			TargetCommitish:       r.Reference.CommitHash,
+			PreviousTagName:       previous,
+			ConfigurationFilePath: config,
+		},
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "error preparing release notes request")
+	}
+
+	notes := new(generatedNotes)
+	if _, err := api.Do(context.Background(), req, notes); err != nil {
+		return "", errors.Wrap(err, "error generating release notes")
+	}
+
+	return notes.Body, nil
+}
+
+// PreviousRelease returns a tag of the latest published release older than the current version
+func (r *Release) PreviousRelease(cli RepositoriesClient, prefix string) (string, error) {
+	releases, err := r.ListReleases(cli)
+	if err != nil {
+		return "", err
+	}
+
+	var previous, version string
+	for _, rel := range releases {
+		if rel.GetDraft() || rel.GetTagName() == r.Reference.Tag {
+			continue
+		}
+
+		ref, err := ParseTag(rel.GetTagName(), prefix, r.versionScheme())
+		if err != nil {
+			continue
+		}
+
+		if r.Reference.Version != "Unreleased" && r.versionScheme().Compare(ref.Version, r.Reference.Version) >= 0 {
+			continue
+		}
+
+		if version == "" || r.versionScheme().Compare(ref.Version, version) > 0 {
+			previous = rel.GetTagName()
+			version = ref.Version
+		}
+	}
+
+	return previous, nil
+}
+
+// CombineNotes merges changelog with generated release notes according to 'mode'
+func CombineNotes(mode, changelog, notes string) string {
+	changelog = strings.TrimRight(changelog, "\n")
+	notes = strings.TrimRight(notes, "\n")
+
+	var parts []string
+	switch mode {
+	case GenerateNotesOnly:
+		parts = []string{notes}
+	case GenerateNotesFallback:
+		if changelog != "" {
+			parts = []string{changelog}
+		} else {
+			parts = []string{notes}
+		}
+	case GenerateNotesAppend:
+		parts = []string{changelog, notes}
+	case GenerateNotesPrepend:
+		parts = []string{notes, changelog}
+	}
+
+	o := make([]string, 0)
+	for _, p := range parts {
+		if p != "" {
+			o = append(o, p)
+		}
+	}
+
+	if len(o) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%v\n", strings.Join(o, "\n\n"))
+}
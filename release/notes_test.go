@@ -0,0 +1,245 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateNotes(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	type test struct {
+		Reference           *release.Reference
+		Prefix              string
+		Config              string
+		ListReleasesMock    [][]*github.RepositoryRelease
+		ExpectedRequestBody map[string]interface{}
+		DoMockError         error
+		Expected            string
+		ExpectedError       string
+	}
+
+	releases := [][]*github.RepositoryRelease{
+		{
+			{TagName: stringP("v1.2.0"), Draft: boolP(true)},
+			{TagName: stringP("v1.1.0")},
+			{TagName: stringP("latest")},
+			{TagName: stringP("web-v1.1.5")},
+			{TagName: stringP("api/v1.0.5")},
+		},
+		{
+			{TagName: stringP("v1.0.0")},
+			{TagName: stringP("v1.1.0-rc.1")},
+		},
+	}
+
+	suite := map[string]test{
+		"Previous Release": {
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.2.0",
+				Version:    "1.2.0",
+			},
+			Config:           ".github/release.yml",
+			ListReleasesMock: releases,
+			ExpectedRequestBody: map[string]interface{}{
+				"tag_name":                "v1.2.0",
+				"target_commitish":        "111",
+				"previous_tag_name":       "v1.1.0",
+				"configuration_file_path": ".github/release.yml",
+			},
+			Expected: "notes",
+		},
+		"Pre-Release": {
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.1.0",
+				Version:    "1.1.0",
+			},
+			ListReleasesMock: releases,
+			ExpectedRequestBody: map[string]interface{}{
+				"tag_name":          "v1.1.0",
+				"target_commitish":  "111",
+				"previous_tag_name": "v1.1.0-rc.1",
+			},
+			Expected: "notes",
+		},
+		"Component": {
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "api/v1.2.0",
+				Version:    "1.2.0",
+				Prefix:     "api/v",
+			},
+			Prefix:           components[0].PrefixRegex(),
+			ListReleasesMock: releases,
+			ExpectedRequestBody: map[string]interface{}{
+				"tag_name":          "api/v1.2.0",
+				"target_commitish":  "111",
+				"previous_tag_name": "api/v1.0.5",
+			},
+			Expected: "notes",
+		},
+		"First Release": {
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.0.0",
+				Version:    "1.0.0",
+			},
+			ListReleasesMock: [][]*github.RepositoryRelease{{}},
+			ExpectedRequestBody: map[string]interface{}{
+				"tag_name":         "v1.0.0",
+				"target_commitish": "111",
+			},
+			Expected: "notes",
+		},
+		"API Error": {
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.0.0",
+				Version:    "1.0.0",
+			},
+			ListReleasesMock: [][]*github.RepositoryRelease{{}},
+			ExpectedRequestBody: map[string]interface{}{
+				"tag_name":         "v1.0.0",
+				"target_commitish": "111",
+			},
+			DoMockError:   errors.New("reason"),
+			ExpectedError: "error generating release notes: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: test.Reference,
+		}
+
+		// test
+		repoMock := new(mocks.RepositoriesClient)
+		apiMock := new(mocks.APIClient)
+
+		for i, page := range test.ListReleasesMock {
+			res := &github.Response{}
+			if i < len(test.ListReleasesMock)-1 {
+				res.NextPage = i + 2
+			}
+
+			opt := &github.ListOptions{PerPage: 100}
+			if i > 0 {
+				opt.Page = i + 1
+			}
+
+			repoMock.On("ListReleases",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				opt).Return(page, res, nil).Once()
+		}
+
+		req := new(http.Request)
+		apiMock.On("NewRequest",
+			"POST",
+			"repos/anton-yurchenko/git-release/releases/generate-notes",
+			mock.MatchedBy(func(body interface{}) bool {
+				b, err := json.Marshal(body)
+				if err != nil {
+					return false
+				}
+
+				m := make(map[string]interface{})
+				if err := json.Unmarshal(b, &m); err != nil {
+					return false
+				}
+
+				return assert.ObjectsAreEqual(test.ExpectedRequestBody, m)
+			})).Return(req, nil).Once()
+
+		apiMock.On("Do",
+			context.Background(),
+			req,
+			mock.Anything).Run(func(args mock.Arguments) {
+			_ = json.Unmarshal([]byte(`{"name":"v1","body":"notes"}`), args.Get(2))
+		}).Return(nil, test.DoMockError).Once()
+
+		notes, err := rel.GenerateNotes(apiMock, repoMock, test.Prefix, test.Config)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		} else {
+			a.Equal(test.Expected, notes)
+		}
+		repoMock.AssertExpectations(t)
+		apiMock.AssertExpectations(t)
+	}
+}
+
+func TestCombineNotes(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Mode      string
+		Changelog string
+		Expected  string
+	}
+
+	suite := map[string]test{
+		"Only": {
+			Mode:      release.GenerateNotesOnly,
+			Changelog: "### Added\n\n- feature\n",
+			Expected:  "notes\n",
+		},
+		"Fallback with Changelog": {
+			Mode:      release.GenerateNotesFallback,
+			Changelog: "### Added\n\n- feature\n",
+			Expected:  "### Added\n\n- feature\n",
+		},
+		"Fallback without Changelog": {
+			Mode:      release.GenerateNotesFallback,
+			Changelog: "",
+			Expected:  "notes\n",
+		},
+		"Append": {
+			Mode:      release.GenerateNotesAppend,
+			Changelog: "### Added\n\n- feature\n",
+			Expected:  "### Added\n\n- feature\n\nnotes\n",
+		},
+		"Prepend": {
+			Mode:      release.GenerateNotesPrepend,
+			Changelog: "### Added\n\n- feature\n",
+			Expected:  "notes\n\n### Added\n\n- feature\n",
+		},
+		"Append without Changelog": {
+			Mode:      release.GenerateNotesAppend,
+			Changelog: "",
+			Expected:  "notes\n",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a.Equal(test.Expected, release.CombineNotes(test.Mode, test.Changelog, "notes\n"))
+	}
+}
@@ -0,0 +1,121 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	changelog "github.com/anton-yurchenko/go-changelog"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	PolicyLatestVersion string = "latest-version"
+	PolicyDefaultBranch string = "default-branch"
+	PolicyChangelogDate string = "changelog-date"
+)
+
+// Policies are checks that should pass before a release is published
+type Policies struct {
+	LatestVersion bool
+	DefaultBranch bool
+	ChangelogDate bool
+
+	// ChangelogMaxAge is a maximum age of a changelog version date in days
+	ChangelogMaxAge int
+}
+
+// CheckPolicies reports all policy violations and returns an error when at least one of them is found
+func (r *Release) CheckPolicies(cli RepositoriesClient, p *Policies, prefix string, today time.Time) error {
+	violations := make([]string, 0)
+
+	if (p.LatestVersion || p.ChangelogDate) && r.Reference.Version == "Unreleased" {
+		log.Warnf("skipping %v and %v policies for Unreleased release", PolicyLatestVersion, PolicyChangelogDate)
+	} else {
+		if p.LatestVersion {
+			v, err := r.checkLatestVersion(cli, prefix)
+			if err != nil {
+				return err
+			}
+
+			if v != "" {
+				violations = append(violations, v)
+			}
+		}
+
+		if p.ChangelogDate {
+			if v := r.checkChangelogDate(p.ChangelogMaxAge, today); v != "" {
+				violations = append(violations, v)
+			}
+		}
+	}
+
+	if p.DefaultBranch {
+		v, err := r.checkDefaultBranch(cli)
+		if err != nil {
+			return err
+		}
+
+		if v != "" {
+			violations = append(violations, v)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	for _, v := range violations {
+		log.Errorf("policy violation: %v", v)
+	}
+
+	return errors.New(fmt.Sprintf("release blocked by %v policy violation(s)", len(violations)))
+}
+
+// checkLatestVersion ensures the version is greater than the version of the latest stable release
+func (r *Release) checkLatestVersion(cli RepositoriesClient, prefix string) (string, error) {
+	latest, version, err := r.LatestRelease(cli, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	if version != "" && r.versionScheme().Compare(r.Reference.Version, version) <= 0 {
+		return fmt.Sprintf("%v: version %v does not exceed version %v of the latest release %v", PolicyLatestVersion, r.Reference.Version, version, latest), nil
+	}
+
+	return "", nil
+}
+
+// checkDefaultBranch ensures the released commit is reachable from the default branch
+func (r *Release) checkDefaultBranch(cli RepositoriesClient) (string, error) {
+	repo, _, err := cli.Get(context.Background(), r.Slug.Owner, r.Slug.Name)
+	if err != nil {
+		return "", errors.Wrap(err, "error retrieving repository")
+	}
+
+	c, _, err := cli.CompareCommits(context.Background(), r.Slug.Owner, r.Slug.Name, r.Reference.CommitHash, repo.GetDefaultBranch())
+	if err != nil {
+		return "", errors.Wrapf(err, "error comparing commit %v with %v branch", r.Reference.CommitHash, repo.GetDefaultBranch())
+	}
+
+	switch c.GetStatus() {
+	case "ahead", "identical":
+		return "", nil
+	default:
+		return fmt.Sprintf("%v: commit %v is not reachable from %v branch (%v)", PolicyDefaultBranch, r.Reference.CommitHash, repo.GetDefaultBranch(), c.GetStatus()), nil
+	}
+}
+
+// checkChangelogDate ensures the changelog version is dated within 'maxAge' days
+func (r *Release) checkChangelogDate(maxAge int, today time.Time) string {
+	if r.Date == nil {
+		return fmt.Sprintf("%v: changelog file does not contain a date of version %v", PolicyChangelogDate, r.Reference.Version)
+	}
+
+	if today.Truncate(24*time.Hour).Sub(*r.Date) > time.Duration(maxAge)*24*time.Hour {
+		return fmt.Sprintf("%v: changelog date %v of version %v is older than %v day(s)", PolicyChangelogDate, r.Date.Format(changelog.DateFormat), r.Reference.Version, maxAge)
+	}
+
+	return ""
+}
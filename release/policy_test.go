@@ -0,0 +1,204 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPolicies(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	today := time.Date(2024, 2, 10, 15, 0, 0, 0, time.UTC)
+	recent := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+	outdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	releases := []*github.RepositoryRelease{
+		{TagName: stringP("v3.0.0"), Draft: boolP(true)},
+		{TagName: stringP("v2.1.0-rc.1"), Prerelease: boolP(true)},
+		{TagName: stringP("v2.0.0")},
+		{TagName: stringP("v1.9.0")},
+		{TagName: stringP("latest")},
+		{TagName: stringP("web-v9.0.0")},
+		{TagName: stringP("api/v2.0.0")},
+	}
+
+	type test struct {
+		Tag              string
+		Prefix           string
+		Version          string
+		Date             *time.Time
+		Policies         *release.Policies
+		CompareStatus    string
+		CompareMockError error
+		ExpectedError    string
+	}
+
+	suite := map[string]test{
+		"All Policies": {
+			Version: "2.1.0",
+			Date:    &recent,
+			Policies: &release.Policies{
+				LatestVersion:   true,
+				DefaultBranch:   true,
+				ChangelogDate:   true,
+				ChangelogMaxAge: 7,
+			},
+			CompareStatus: "ahead",
+		},
+		"Older Version": {
+			Version: "1.9.1",
+			Policies: &release.Policies{
+				LatestVersion: true,
+			},
+			ExpectedError: "release blocked by 1 policy violation(s)",
+		},
+		"Existing Release": {
+			Version: "2.0.0",
+			Policies: &release.Policies{
+				LatestVersion: true,
+			},
+		},
+		"Component Latest Version": {
+			Tag:     "api/v2.1.0",
+			Prefix:  components[0].PrefixRegex(),
+			Version: "2.1.0",
+			Policies: &release.Policies{
+				LatestVersion: true,
+			},
+		},
+		"Component Older Version": {
+			Tag:     "api/v1.9.1",
+			Prefix:  components[0].PrefixRegex(),
+			Version: "1.9.1",
+			Policies: &release.Policies{
+				LatestVersion: true,
+			},
+			ExpectedError: "release blocked by 1 policy violation(s)",
+		},
+		"Maintenance Branch": {
+			Version: "2.1.0",
+			Policies: &release.Policies{
+				DefaultBranch: true,
+			},
+			CompareStatus: "diverged",
+			ExpectedError: "release blocked by 1 policy violation(s)",
+		},
+		"Default Branch Head": {
+			Version: "2.1.0",
+			Policies: &release.Policies{
+				DefaultBranch: true,
+			},
+			CompareStatus: "identical",
+		},
+		"Compare Error": {
+			Version: "2.1.0",
+			Policies: &release.Policies{
+				DefaultBranch: true,
+			},
+			CompareMockError: errors.New("reason"),
+			ExpectedError:    "error comparing commit 111 with main branch: reason",
+		},
+		"Outdated Changelog": {
+			Version: "2.1.0",
+			Date:    &outdated,
+			Policies: &release.Policies{
+				ChangelogDate:   true,
+				ChangelogMaxAge: 7,
+			},
+			ExpectedError: "release blocked by 1 policy violation(s)",
+		},
+		"Missing Changelog Date": {
+			Version: "2.1.0",
+			Policies: &release.Policies{
+				ChangelogDate:   true,
+				ChangelogMaxAge: 7,
+			},
+			ExpectedError: "release blocked by 1 policy violation(s)",
+		},
+		"Multiple Violations": {
+			Version: "1.0.0",
+			Date:    &outdated,
+			Policies: &release.Policies{
+				LatestVersion:   true,
+				DefaultBranch:   true,
+				ChangelogDate:   true,
+				ChangelogMaxAge: 30,
+			},
+			CompareStatus: "behind",
+			ExpectedError: "release blocked by 3 policy violation(s)",
+		},
+		"Unreleased": {
+			Version: "Unreleased",
+			Policies: &release.Policies{
+				LatestVersion: true,
+				ChangelogDate: true,
+			},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		tag := test.Tag
+		if tag == "" {
+			tag = "v" + test.Version
+		}
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        tag,
+				Version:    test.Version,
+			},
+			Date: test.Date,
+		}
+
+		// test
+		m := new(mocks.RepositoriesClient)
+
+		if test.Policies.LatestVersion && test.Version != "Unreleased" {
+			m.On("ListReleases",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.ListOptions{PerPage: 100}).Return(releases, &github.Response{}, nil).Once()
+		}
+
+		if test.Policies.DefaultBranch {
+			m.On("Get",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name).Return(&github.Repository{DefaultBranch: stringP("main")}, nil, nil).Once()
+
+			m.On("CompareCommits",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"111",
+				"main").Return(&github.CommitsComparison{Status: stringP(test.CompareStatus)}, nil, test.CompareMockError).Once()
+		}
+
+		err := rel.CheckPolicies(m, test.Policies, test.Prefix, today)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,92 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Preflight verifies that a token is able to publish a release before anything is changed:
+// the repository is accessible and writable and a release with the same tag does not exist yet (unless 'update' is set)
+func (r *Release) Preflight(cli RepositoriesClient, update bool) error {
+	repo, _, err := cli.Get(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") || strings.Contains(err.Error(), "401 Bad credentials") {
+			return errors.New(fmt.Sprintf("repository %v/%v not found, make sure the token has access to it", r.Slug.Owner, r.Slug.Name))
+		}
+
+		if strings.Contains(err.Error(), "403") {
+			return errors.New(fmt.Sprintf("token can not read repository %v/%v, add `permissions: contents: write` to the workflow", r.Slug.Owner, r.Slug.Name))
+		}
+
+		return errors.Wrapf(err, "error retrieving repository %v/%v", r.Slug.Owner, r.Slug.Name)
+	}
+
+	// permissions are not reported for GITHUB_TOKEN and GitHub App tokens
+	if repo.Permissions == nil {
+		log.Warn("token permissions are not reported, skipping write access check")
+	} else if !(*repo.Permissions)["push"] {
+		return errors.New("token lacks contents:write, add `permissions: contents: write` to the workflow or grant the token write access to the repository")
+	}
+
+	if !update {
+		existing, _, err := cli.GetReleaseByTag(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			r.Reference.Tag,
+		)
+		if err == nil {
+			return errors.New(fmt.Sprintf("release with a tag %v already exists (%v), set UPDATE_EXISTING to upload assets into it", r.Reference.Tag, existing.GetHTMLURL()))
+		} else if !strings.Contains(err.Error(), "404 Not Found") {
+			return errors.Wrapf(err, "error retrieving a release with a tag %v", r.Reference.Tag)
+		}
+	}
+
+	return nil
+}
+
+// CheckRateLimit verifies that enough API calls are left within the rate limit to publish a release with all its assets
+func (r *Release) CheckRateLimit(cli RateLimitClient) error {
+	limits, _, err := cli.RateLimits(context.Background())
+	if err != nil {
+		// rate limiting may be disabled on GitHub Enterprise Server
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return nil
+		}
+
+		return errors.Wrap(err, "error retrieving rate limit")
+	}
+
+	core := limits.GetCore()
+	if core == nil {
+		return nil
+	}
+
+	expected := r.ExpectedCalls()
+	if core.Remaining < expected {
+		return errors.New(fmt.Sprintf("rate limit too low: %v API calls left while about %v are expected, retry after %v", core.Remaining, expected, core.Reset.Time.UTC().Format(time.RFC3339)))
+	}
+
+	log.Infof("rate limit: %v/%v API calls left, about %v expected", core.Remaining, core.Limit, expected)
+	return nil
+}
+
+// ExpectedCalls estimates a number of API calls made by a release: several calls for the release itself
+// and two calls (upload and a possible replacement) per asset
+func (r *Release) ExpectedCalls() int {
+	n := 10
+	if r.Assets != nil {
+		n += 2 * len(*r.Assets)
+	}
+
+	return n
+}
@@ -0,0 +1,189 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreflight(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	notFound := errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release/releases/tags/v1.0.0: 404 Not Found []")
+
+	type test struct {
+		Update             bool
+		Permissions        *map[string]bool
+		GetRepoError       error
+		Release            *github.RepositoryRelease
+		GetReleaseError    error
+		ExpectedGetRelease bool
+		ExpectedError      string
+	}
+
+	suite := map[string]test{
+		"Success": {
+			Permissions:        &map[string]bool{"push": true},
+			GetReleaseError:    notFound,
+			ExpectedGetRelease: true,
+		},
+		"Permissions Not Reported": {
+			GetReleaseError:    notFound,
+			ExpectedGetRelease: true,
+		},
+		"Read Only Token": {
+			Permissions:   &map[string]bool{"pull": true, "push": false},
+			ExpectedError: "token lacks contents:write, add `permissions: contents: write` to the workflow or grant the token write access to the repository",
+		},
+		"Repository Not Found": {
+			GetRepoError:  errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release: 404 Not Found []"),
+			ExpectedError: "repository anton-yurchenko/git-release not found, make sure the token has access to it",
+		},
+		"Repository Forbidden": {
+			GetRepoError:  errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release: 403 Resource not accessible by integration []"),
+			ExpectedError: "token can not read repository anton-yurchenko/git-release, add `permissions: contents: write` to the workflow",
+		},
+		"Existing Release": {
+			Permissions:        &map[string]bool{"push": true},
+			Release:            &github.RepositoryRelease{HTMLURL: stringP("https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0")},
+			ExpectedGetRelease: true,
+			ExpectedError:      "release with a tag v1.0.0 already exists (https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0), set UPDATE_EXISTING to upload assets into it",
+		},
+		"Existing Release in Update Mode": {
+			Permissions: &map[string]bool{"push": true},
+			Update:      true,
+		},
+		"Release Error": {
+			Permissions:        &map[string]bool{"push": true},
+			GetReleaseError:    errors.New("reason"),
+			ExpectedGetRelease: true,
+			ExpectedError:      "error retrieving a release with a tag v1.0.0: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.0.0",
+				Version:    "1.0.0",
+			},
+		}
+
+		// test
+		m := new(mocks.RepositoriesClient)
+
+		m.On("Get",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name).Return(&github.Repository{Permissions: test.Permissions}, nil, test.GetRepoError).Once()
+
+		if test.ExpectedGetRelease {
+			m.On("GetReleaseByTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				rel.Reference.Tag).Return(test.Release, nil, test.GetReleaseError).Once()
+		}
+
+		err := rel.Preflight(m, test.Update)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+	}
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	reset := github.Timestamp{Time: time.Date(2024, 3, 5, 13, 0, 0, 0, time.UTC)}
+
+	type test struct {
+		Assets          []release.Asset
+		Remaining       int
+		RateLimitsError error
+		ExpectedError   string
+	}
+
+	assets := []release.Asset{
+		{Name: "darwin.zip", Path: "build/darwin.zip"},
+		{Name: "linux.zip", Path: "build/linux.zip"},
+	}
+
+	suite := map[string]test{
+		"Success": {
+			Assets:    assets,
+			Remaining: 1000,
+		},
+		"Rate Limit Too Low": {
+			Assets:        assets,
+			Remaining:     5,
+			ExpectedError: "rate limit too low: 5 API calls left while about 14 are expected, retry after 2024-03-05T13:00:00Z",
+		},
+		"Rate Limit Too Low for Generated Assets": {
+			Assets: append(assets,
+				release.Asset{Name: "darwin.zip.sbom.json", Path: "build/darwin.zip.sbom.json", Generated: true},
+				release.Asset{Name: "multiple.intoto.jsonl", Path: "multiple.intoto.jsonl", Generated: true},
+			),
+			Remaining:     14,
+			ExpectedError: "rate limit too low: 14 API calls left while about 18 are expected, retry after 2024-03-05T13:00:00Z",
+		},
+		"Rate Limiting Disabled": {
+			Assets:          assets,
+			RateLimitsError: errors.New("GET https://github.example.com/api/v3/rate_limit: 404 Not Found []"),
+		},
+		"Rate Limit Error": {
+			Assets:          assets,
+			RateLimitsError: errors.New("reason"),
+			ExpectedError:   "error retrieving rate limit: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Assets: &test.Assets,
+		}
+
+		// test
+		m := new(mocks.RateLimitClient)
+
+		m.On("RateLimits",
+			context.Background()).Return(&github.RateLimits{
+			Core: &github.Rate{
+				Limit:     5000,
+				Remaining: test.Remaining,
+				Reset:     reset,
+			},
+		}, nil, test.RateLimitsError).Once()
+
+		err := rel.CheckRateLimit(m)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+	}
+}
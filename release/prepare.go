@@ -0,0 +1,266 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	changelog "github.com/anton-yurchenko/go-changelog"
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// PromoteUnreleased moves changes of the Unreleased section into a new version section dated 'date'
+// and updates compare links of both sections.
+// 'repository' is used as a links base when it cannot be extracted from existing links.
+func PromoteUnreleased(content, version, tag, date, repository string) (string, error) {
+	unreleasedTitle := regexp.MustCompile(`^## \[Unreleased\](?:\((?P<url>[^)]+)\))?$`)
+	unreleasedLink := regexp.MustCompile(`^\[Unreleased\]: (?P<url>\S+)$`)
+	versionTitle := regexp.MustCompile(fmt.Sprintf(`^## \[%v\]`, regexp.QuoteMeta(version)))
+	versionLink := regexp.MustCompile(fmt.Sprintf(`^\[%v\]: (?P<url>\S+)$`, changelog.SemVerRegex))
+	link := regexp.MustCompile(`^\[[^\]]+\]: \S+$`)
+
+	lines := strings.Split(content, "\n")
+
+	title, unreleased, first := -1, -1, -1
+	var inline, open, changes bool
+	var url string
+	for i, l := range lines {
+		line := strings.TrimRight(l, " \t\r")
+
+		switch {
+		case unreleasedTitle.MatchString(line):
+			title = i
+			open = true
+
+			if u := unreleasedTitle.FindStringSubmatch(line)[1]; u != "" {
+				inline = true
+				url = u
+			}
+		case versionTitle.MatchString(line):
+			return "", errors.New(fmt.Sprintf("changelog file already contains version %v", version))
+		case strings.HasPrefix(line, "## "):
+			open = false
+		case unreleasedLink.MatchString(line):
+			open = false
+			unreleased = i
+
+			if url == "" {
+				url = unreleasedLink.FindStringSubmatch(line)[1]
+			}
+		case versionLink.MatchString(line):
+			open = false
+
+			if first == -1 {
+				first = i
+			}
+		case link.MatchString(line):
+			open = false
+		case open && i != title && !strings.HasPrefix(line, "### ") && strings.TrimSpace(line) != "":
+			changes = true
+		}
+	}
+
+	if title == -1 {
+		return "", errors.New("changelog file does not contain Unreleased section")
+	}
+
+	if !changes {
+		return "", errors.New("changelog file does not contain changes in Unreleased scope")
+	}
+
+	if url == "" && first != -1 {
+		url = versionLink.FindStringSubmatch(strings.TrimRight(lines[first], " \t\r"))[versionLink.SubexpIndex("url")]
+	}
+
+	base, previous := parseLink(url)
+	if base == "" {
+		base = repository
+	}
+
+	unreleasedURL := fmt.Sprintf("%v/compare/%v...HEAD", base, tag)
+	versionURL := fmt.Sprintf("%v/releases/tag/%v", base, tag)
+	if previous != "" {
+		versionURL = fmt.Sprintf("%v/compare/%v...%v", base, previous, tag)
+	}
+
+	output := make([]string, 0, len(lines)+4)
+	for i, line := range lines {
+		switch {
+		case i == title && inline:
+			output = append(output,
+				fmt.Sprintf("## [Unreleased](%v)", unreleasedURL),
+				"",
+				fmt.Sprintf("## [%v](%v) - %v", version, versionURL, date),
+			)
+		case i == title:
+			output = append(output,
+				"## [Unreleased]",
+				"",
+				fmt.Sprintf("## [%v] - %v", version, date),
+			)
+		case i == unreleased:
+			output = append(output,
+				fmt.Sprintf("[Unreleased]: %v", unreleasedURL),
+				fmt.Sprintf("[%v]: %v", version, versionURL),
+			)
+		case i == first && unreleased == -1 && !inline:
+			output = append(output,
+				fmt.Sprintf("[%v]: %v", version, versionURL),
+				line,
+			)
+		default:
+			output = append(output, line)
+		}
+	}
+
+	if unreleased == -1 && first == -1 && !inline {
+		if output[len(output)-1] == "" {
+			output = output[:len(output)-1]
+		}
+
+		output = append(output,
+			"",
+			fmt.Sprintf("[%v]: %v", version, versionURL),
+			"",
+		)
+	}
+
+	return strings.Join(output, "\n"), nil
+}
+
+// parseLink extracts a repository URL and the latest tag out of a compare/release link
+func parseLink(url string) (string, string) {
+	compare := regexp.MustCompile(`^(?P<base>.+)/compare/(?P<from>.+)\.\.\.(?P<to>.+)$`)
+	tag := regexp.MustCompile(`^(?P<base>.+)/releases/tag/(?P<tag>.+)$`)
+
+	if m := compare.FindStringSubmatch(url); m != nil {
+		if m[3] == "HEAD" {
+			return m[1], m[2]
+		}
+
+		return m[1], m[3]
+	}
+
+	if m := tag.FindStringSubmatch(url); m != nil {
+		return m[1], m[2]
+	}
+
+	return "", ""
+}
+
+// CommitChangelog commits an updated changelog file on top of a 'branch' using Git Data API.
+// The branch is expected to point at the workflow commit, so that the changelog is not committed over unseen changes.
+// Release tag is created pointing to the new commit when 'tag' is set.
+func (r *Release) CommitChangelog(cli GitClient, branch, file, content string, tag bool) error {
+	head, _, err := cli.GetRef(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		fmt.Sprintf("refs/heads/%v", branch),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving branch %v", branch)
+	}
+
+	if head.GetObject().GetSHA() != r.Reference.CommitHash {
+		return errors.New(fmt.Sprintf("branch %v moved to %v since GITHUB_SHA %v, re-run the workflow on the latest commit", branch, head.GetObject().GetSHA(), r.Reference.CommitHash))
+	}
+
+	parent, _, err := cli.GetCommit(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		head.GetObject().GetSHA(),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving commit %v", head.GetObject().GetSHA())
+	}
+
+	blob, _, err := cli.CreateBlob(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		&github.Blob{
+			Content:  github.String(content),
+			Encoding: github.String("utf-8"),
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "error creating blob")
+	}
+
+	tree, _, err := cli.CreateTree(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		parent.GetTree().GetSHA(),
+		[]github.TreeEntry{
+			{
+				Path: github.String(file),
+				Mode: github.String("100644"),
+				Type: github.String("blob"),
+				SHA:  blob.SHA,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "error creating tree")
+	}
+
+	commit, _, err := cli.CreateCommit(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		&github.Commit{
+			Message: github.String(fmt.Sprintf("Release %v", r.Reference.Tag)),
+			Tree:    &github.Tree{SHA: tree.SHA},
+			Parents: []github.Commit{{SHA: parent.SHA}},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "error creating commit")
+	}
+
+	_, _, err = cli.UpdateRef(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		&github.Reference{
+			Ref: github.String(fmt.Sprintf("refs/heads/%v", branch)),
+			Object: &github.GitObject{
+				SHA: commit.SHA,
+			},
+		},
+		false,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error updating branch %v", branch)
+	}
+	r.Reference.CommitHash = commit.GetSHA()
+
+	log.WithField("commit", commit.GetSHA()).Infof("changelog committed to %v 🎉", branch)
+
+	if tag {
+		_, _, err := cli.CreateRef(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			&github.Reference{
+				Ref: github.String(fmt.Sprintf("refs/tags/%v", r.Reference.Tag)),
+				Object: &github.GitObject{
+					SHA: commit.SHA,
+				},
+			},
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error creating %v tag", r.Reference.Tag)
+		}
+
+		log.Infof("tag %v created 🎉", r.Reference.Tag)
+	}
+
+	return nil
+}
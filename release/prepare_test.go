@@ -0,0 +1,359 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoteUnreleased(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Content       string
+		Expected      string
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"Markdown Links": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Feature B
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+[Unreleased]: https://github.com/anton-yurchenko/git-release/compare/v1.0.0...HEAD
+[1.0.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0
+`,
+			Expected: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-02-01
+
+### Added
+
+- Feature B
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+[Unreleased]: https://github.com/anton-yurchenko/git-release/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/anton-yurchenko/git-release/compare/v1.0.0...v1.1.0
+[1.0.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0
+`,
+		},
+		"Markdown Links without Unreleased": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+- Notice
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+[1.0.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0
+`,
+			Expected: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-02-01
+
+- Notice
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+[1.1.0]: https://github.com/anton-yurchenko/git-release/compare/v1.0.0...v1.1.0
+[1.0.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0
+`,
+		},
+		"Inline Links": {
+			Content: `# Changelog
+
+## [Unreleased](https://github.com/anton-yurchenko/git-release/compare/v1.0.0...HEAD)
+
+### Added
+
+- Feature B
+
+## [1.0.0](https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0) - 2024-01-01
+
+### Added
+
+- Feature A
+`,
+			Expected: `# Changelog
+
+## [Unreleased](https://github.com/anton-yurchenko/git-release/compare/v1.1.0...HEAD)
+
+## [1.1.0](https://github.com/anton-yurchenko/git-release/compare/v1.0.0...v1.1.0) - 2024-02-01
+
+### Added
+
+- Feature B
+
+## [1.0.0](https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0) - 2024-01-01
+
+### Added
+
+- Feature A
+`,
+		},
+		"First Release": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Feature A
+`,
+			Expected: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-02-01
+
+### Added
+
+- Feature A
+
+[1.1.0]: https://github.com/owner/repo/releases/tag/v1.1.0
+`,
+		},
+		"Empty Unreleased": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+### Added
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+[1.0.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0
+`,
+			ExpectedError: "changelog file does not contain changes in Unreleased scope",
+		},
+		"Missing Unreleased": {
+			Content: `# Changelog
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+`,
+			ExpectedError: "changelog file does not contain Unreleased section",
+		},
+		"Existing Version": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+- Notice
+
+## [1.1.0] - 2024-01-01
+
+### Added
+
+- Feature A
+`,
+			ExpectedError: "changelog file already contains version 1.1.0",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		content, err := release.PromoteUnreleased(test.Content, "1.1.0", "v1.1.0", "2024-02-01", "https://github.com/owner/repo")
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, content)
+	}
+}
+
+func TestCommitChangelog(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	type test struct {
+		Tag                   bool
+		HeadSHA               string
+		GetRefMockError       error
+		CreateCommitMockError error
+		UpdateRefMockError    error
+		CreateRefMockError    error
+		ExpectedError         string
+	}
+
+	suite := map[string]test{
+		"Success": {
+			ExpectedError: "",
+		},
+		"Success with Tag": {
+			Tag:           true,
+			ExpectedError: "",
+		},
+		"Missing Branch": {
+			GetRefMockError: errors.New("reason"),
+			ExpectedError:   "error retrieving branch main: reason",
+		},
+		"Branch Ahead of Workflow Commit": {
+			HeadSHA:       "333",
+			ExpectedError: "branch main moved to 333 since GITHUB_SHA 111, re-run the workflow on the latest commit",
+		},
+		"Commit Error": {
+			CreateCommitMockError: errors.New("reason"),
+			ExpectedError:         "error creating commit: reason",
+		},
+		"Branch Moved": {
+			UpdateRefMockError: errors.New("422 Update is not a fast forward"),
+			ExpectedError:      "error updating branch main: 422 Update is not a fast forward",
+		},
+		"Tag Error": {
+			Tag:                true,
+			CreateRefMockError: errors.New("reason"),
+			ExpectedError:      "error creating v1.1.0 tag: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.1.0",
+				Version:    "1.1.0",
+			},
+		}
+
+		head := "111"
+		if test.HeadSHA != "" {
+			head = test.HeadSHA
+		}
+
+		// test
+		m := new(mocks.GitClient)
+
+		m.On("GetRef",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name,
+			"refs/heads/main").Return(&github.Reference{Object: &github.GitObject{SHA: stringP(head)}}, nil, test.GetRefMockError).Once()
+
+		if test.GetRefMockError == nil && test.HeadSHA == "" {
+			m.On("GetCommit",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"111").Return(&github.Commit{SHA: stringP("111"), Tree: &github.Tree{SHA: stringP("aaa")}}, nil, nil).Once()
+
+			m.On("CreateBlob",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.Blob{
+					Content:  stringP("changelog"),
+					Encoding: stringP("utf-8"),
+				}).Return(&github.Blob{SHA: stringP("bbb")}, nil, nil).Once()
+
+			m.On("CreateTree",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"aaa",
+				[]github.TreeEntry{
+					{
+						Path: stringP("CHANGELOG.md"),
+						Mode: stringP("100644"),
+						Type: stringP("blob"),
+						SHA:  stringP("bbb"),
+					},
+				}).Return(&github.Tree{SHA: stringP("ccc")}, nil, nil).Once()
+
+			m.On("CreateCommit",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.Commit{
+					Message: stringP("Release v1.1.0"),
+					Tree:    &github.Tree{SHA: stringP("ccc")},
+					Parents: []github.Commit{{SHA: stringP("111")}},
+				}).Return(&github.Commit{SHA: stringP("222")}, nil, test.CreateCommitMockError).Once()
+		}
+
+		if test.GetRefMockError == nil && test.HeadSHA == "" && test.CreateCommitMockError == nil {
+			m.On("UpdateRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.Reference{
+					Ref:    stringP("refs/heads/main"),
+					Object: &github.GitObject{SHA: stringP("222")},
+				},
+				false).Return(nil, nil, test.UpdateRefMockError).Once()
+		}
+
+		if test.Tag && test.UpdateRefMockError == nil {
+			m.On("CreateRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.Reference{
+					Ref:    stringP("refs/tags/v1.1.0"),
+					Object: &github.GitObject{SHA: stringP("222")},
+				}).Return(nil, nil, test.CreateRefMockError).Once()
+		}
+
+		err := rel.CommitChangelog(m, "main", "CHANGELOG.md", "changelog", test.Tag)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		} else {
+			a.Equal("222", rel.Reference.CommitHash)
+		}
+		m.AssertExpectations(t)
+	}
+}
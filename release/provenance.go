@@ -0,0 +1,311 @@
+package release
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const (
+	ProvenanceFilename    string = "multiple.intoto.jsonl"
+	InTotoStatementType   string = "https://in-toto.io/Statement/v1"
+	InTotoPayloadType     string = "application/vnd.in-toto+json"
+	SLSAProvenanceType    string = "https://slsa.dev/provenance/v1"
+	GitHubWorkflowBuildV1 string = "https://actions.github.io/buildtypes/workflow/v1"
+)
+
+type inTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []inTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     slsaProvenance  `json:"predicate"`
+}
+
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type slsaProvenance struct {
+	BuildDefinition slsaBuildDefinition `json:"buildDefinition"`
+	RunDetails      slsaRunDetails      `json:"runDetails"`
+}
+
+type slsaBuildDefinition struct {
+	BuildType            string                   `json:"buildType"`
+	ExternalParameters   map[string]interface{}   `json:"externalParameters"`
+	InternalParameters   map[string]interface{}   `json:"internalParameters"`
+	ResolvedDependencies []slsaResourceDescriptor `json:"resolvedDependencies"`
+}
+
+type slsaResourceDescriptor struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+type slsaRunDetails struct {
+	Builder  slsaBuilder  `json:"builder"`
+	Metadata slsaMetadata `json:"metadata"`
+}
+
+type slsaBuilder struct {
+	ID string `json:"id"`
+}
+
+type slsaMetadata struct {
+	InvocationID string `json:"invocationId"`
+}
+
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// GenerateProvenance writes an in-toto provenance attestation of release assets into 'dir' and attaches it to the release.
+// Attestation is signed when a 'key' is provided.
+func (r *Release) GenerateProvenance(fs afero.Fs, dir string, key crypto.Signer) error {
+	subjects := make([]inTotoSubject, 0)
+	if r.Assets != nil {
+		for _, a := range *r.Assets {
+			if a.Name == ProvenanceFilename {
+				continue
+			}
+
+			digest, err := a.Digest(fs)
+			if err != nil {
+				return errors.Wrapf(err, "error calculating digest of asset %v", a.Name)
+			}
+
+			subjects = append(subjects, inTotoSubject{
+				Name:   strings.ReplaceAll(a.Name, "/", "-"),
+				Digest: map[string]string{"sha256": digest},
+			})
+		}
+	}
+
+	b, err := json.Marshal(r.newStatement(subjects))
+	if err != nil {
+		return errors.Wrap(err, "error encoding provenance statement")
+	}
+
+	envelope, err := newEnvelope(b, key)
+	if err != nil {
+		return err
+	}
+
+	e, err := json.Marshal(envelope)
+	if err != nil {
+		return errors.Wrap(err, "error encoding provenance envelope")
+	}
+
+	provenance := Asset{
+		Name:      ProvenanceFilename,
+		Path:      filepath.Join(dir, ProvenanceFilename),
+		Generated: true,
+	}
+
+	if err := afero.WriteFile(fs, provenance.Path, append(e, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "error writing provenance")
+	}
+
+	if r.Assets == nil {
+		r.Assets = &[]Asset{}
+	}
+
+	attached := false
+	for i, a := range *r.Assets {
+		if a.Name == ProvenanceFilename {
+			(*r.Assets)[i].Generated = true
+			attached = true
+			break
+		}
+	}
+	if !attached {
+		*r.Assets = append(*r.Assets, provenance)
+	}
+
+	if key == nil {
+		log.Warn("provenance generated without a signature")
+	} else {
+		log.Info("provenance generated and signed")
+	}
+
+	return nil
+}
+
+// Digest returns a hex encoded SHA-256 digest of an asset
+func (a *Asset) Digest(fs afero.Fs) (string, error) {
+	file, err := fs.Open(a.Path)
+	if err != nil {
+		return "", errors.Wrap(err, "error opening a file")
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", errors.Wrap(err, "error reading a file")
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (r *Release) newStatement(subjects []inTotoSubject) *inTotoStatement {
+	server := strings.TrimSuffix(os.Getenv("GITHUB_SERVER_URL"), "/")
+	repository := fmt.Sprintf("%v/%v", server, os.Getenv("GITHUB_REPOSITORY"))
+
+	builder := fmt.Sprintf("%v/%v", server, os.Getenv("GITHUB_WORKFLOW_REF"))
+	if os.Getenv("GITHUB_WORKFLOW_REF") == "" {
+		builder = fmt.Sprintf("%v/actions", repository)
+	}
+
+	var workflow string
+	if s := strings.SplitN(os.Getenv("GITHUB_WORKFLOW_REF"), "@", 2); len(s) == 2 {
+		workflow = strings.TrimPrefix(s[0], os.Getenv("GITHUB_REPOSITORY")+"/")
+	}
+
+	invocation := fmt.Sprintf("%v/actions/runs/%v", repository, os.Getenv("GITHUB_RUN_ID"))
+	if os.Getenv("GITHUB_RUN_ATTEMPT") != "" {
+		invocation = fmt.Sprintf("%v/attempts/%v", invocation, os.Getenv("GITHUB_RUN_ATTEMPT"))
+	}
+
+	return &inTotoStatement{
+		Type:          InTotoStatementType,
+		Subject:       subjects,
+		PredicateType: SLSAProvenanceType,
+		Predicate: slsaProvenance{
+			BuildDefinition: slsaBuildDefinition{
+				BuildType: GitHubWorkflowBuildV1,
+				ExternalParameters: map[string]interface{}{
+					"workflow": map[string]string{
+						"ref":        os.Getenv("GITHUB_REF"),
+						"repository": repository,
+						"path":       workflow,
+					},
+				},
+				InternalParameters: map[string]interface{}{
+					"github": map[string]string{
+						"event_name":          os.Getenv("GITHUB_EVENT_NAME"),
+						"repository_id":       os.Getenv("GITHUB_REPOSITORY_ID"),
+						"repository_owner_id": os.Getenv("GITHUB_REPOSITORY_OWNER_ID"),
+						"workflow":            os.Getenv("GITHUB_WORKFLOW"),
+						"run_id":              os.Getenv("GITHUB_RUN_ID"),
+						"run_attempt":         os.Getenv("GITHUB_RUN_ATTEMPT"),
+					},
+				},
+				ResolvedDependencies: []slsaResourceDescriptor{
+					{
+						URI:    fmt.Sprintf("git+%v@%v", repository, os.Getenv("GITHUB_REF")),
+						Digest: map[string]string{"gitCommit": r.Reference.CommitHash},
+					},
+				},
+			},
+			RunDetails: slsaRunDetails{
+				Builder: slsaBuilder{
+					ID: builder,
+				},
+				Metadata: slsaMetadata{
+					InvocationID: invocation,
+				},
+			},
+		},
+	}
+}
+
+func newEnvelope(payload []byte, key crypto.Signer) (*dsseEnvelope, error) {
+	envelope := &dsseEnvelope{
+		PayloadType: InTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  make([]dsseSignature, 0),
+	}
+
+	if key == nil {
+		return envelope, nil
+	}
+
+	// DSSE pre-authentication encoding
+	pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(InTotoPayloadType), InTotoPayloadType, len(payload), payload))
+
+	var sig []byte
+	var err error
+	switch key.(type) {
+	case ed25519.PrivateKey:
+		sig, err = key.Sign(rand.Reader, pae, crypto.Hash(0))
+	default:
+		digest := sha256.Sum256(pae)
+		sig, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error signing provenance")
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding public key")
+	}
+	id := sha256.Sum256(public)
+
+	envelope.Signatures = append(envelope.Signatures, dsseSignature{
+		KeyID: hex.EncodeToString(id[:]),
+		Sig:   base64.StdEncoding.EncodeToString(sig),
+	})
+
+	return envelope, nil
+}
+
+// ParseSigningKey loads a PEM encoded unencrypted ECDSA/Ed25519/RSA private key
+func ParseSigningKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("error decoding signing key: PEM block not found")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, errors.New(fmt.Sprintf("unsupported signing key type %v", block.Type))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing signing key")
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	case *rsa.PrivateKey:
+		return k, nil
+	}
+
+	return nil, errors.New("unsupported signing key algorithm")
+}
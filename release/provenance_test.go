@@ -0,0 +1,232 @@
+package release_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"git-release/release"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func pemPrivateKey(t *testing.T, key crypto.Signer) []byte {
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("error preparing test case: error encoding private key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b})
+}
+
+func TestGenerateProvenance(t *testing.T) {
+	log.SetOutput(io.Discard)
+
+	a := assert.New(t)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating key: %v", err)
+	}
+
+	env := map[string]string{
+		"GITHUB_SERVER_URL":   "https://github.com",
+		"GITHUB_REPOSITORY":   "anton-yurchenko/git-release",
+		"GITHUB_WORKFLOW_REF": "anton-yurchenko/git-release/.github/workflows/release.yml@refs/tags/v1.0.0",
+		"GITHUB_REF":          "refs/tags/v1.0.0",
+		"GITHUB_RUN_ID":       "123",
+		"GITHUB_RUN_ATTEMPT":  "2",
+	}
+
+	type test struct {
+		Key crypto.Signer
+	}
+
+	suite := map[string]test{
+		"Unsigned": {
+			Key: nil,
+		},
+		"Ed25519": {
+			Key: edKey,
+		},
+		"ECDSA": {
+			Key: ecKey,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		fs := afero.NewMemMapFs()
+		if err := afero.WriteFile(fs, "file1", []byte("content"), 0644); err != nil {
+			t.Errorf("error preparing test case: error creating file file1: %v", err)
+			continue
+		}
+
+		for k, v := range env {
+			if err := os.Setenv(k, v); err != nil {
+				t.Errorf("error preparing test case: error setting environmental variable %v=%v: %v", k, v, err)
+				continue
+			}
+		}
+
+		rel := &release.Release{
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.0.0",
+				Version:    "1.0.0",
+			},
+			Assets: &[]release.Asset{
+				{
+					Name: "file1",
+					Path: "file1",
+				},
+			},
+		}
+
+		// test
+		a.Nil(rel.GenerateProvenance(fs, "/workspace", test.Key))
+		a.Equal(&[]release.Asset{
+			{
+				Name: "file1",
+				Path: "file1",
+			},
+			{
+				Name:      release.ProvenanceFilename,
+				Path:      "/workspace/" + release.ProvenanceFilename,
+				Generated: true,
+			},
+		}, rel.Assets)
+
+		b, err := afero.ReadFile(fs, "/workspace/"+release.ProvenanceFilename)
+		a.Nil(err)
+
+		envelope := struct {
+			PayloadType string `json:"payloadType"`
+			Payload     string `json:"payload"`
+			Signatures  []struct {
+				KeyID string `json:"keyid"`
+				Sig   string `json:"sig"`
+			} `json:"signatures"`
+		}{}
+		a.Nil(json.Unmarshal(b, &envelope))
+		a.Equal(release.InTotoPayloadType, envelope.PayloadType)
+
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		a.Nil(err)
+
+		statement := struct {
+			Type    string `json:"_type"`
+			Subject []struct {
+				Name   string            `json:"name"`
+				Digest map[string]string `json:"digest"`
+			} `json:"subject"`
+			PredicateType string `json:"predicateType"`
+			Predicate     struct {
+				RunDetails struct {
+					Builder struct {
+						ID string `json:"id"`
+					} `json:"builder"`
+					Metadata struct {
+						InvocationID string `json:"invocationId"`
+					} `json:"metadata"`
+				} `json:"runDetails"`
+			} `json:"predicate"`
+		}{}
+		a.Nil(json.Unmarshal(payload, &statement))
+		a.Equal(release.InTotoStatementType, statement.Type)
+		a.Equal(release.SLSAProvenanceType, statement.PredicateType)
+		a.Len(statement.Subject, 1)
+		a.Equal("file1", statement.Subject[0].Name)
+		a.Equal(fmt.Sprintf("%x", sha256.Sum256([]byte("content"))), statement.Subject[0].Digest["sha256"])
+		a.Equal("https://github.com/anton-yurchenko/git-release/.github/workflows/release.yml@refs/tags/v1.0.0", statement.Predicate.RunDetails.Builder.ID)
+		a.Equal("https://github.com/anton-yurchenko/git-release/actions/runs/123/attempts/2", statement.Predicate.RunDetails.Metadata.InvocationID)
+
+		pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(envelope.PayloadType), envelope.PayloadType, len(payload), payload))
+		switch key := test.Key.(type) {
+		case nil:
+			a.Len(envelope.Signatures, 0)
+		case ed25519.PrivateKey:
+			a.Len(envelope.Signatures, 1)
+			sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			a.Nil(err)
+			a.True(ed25519.Verify(key.Public().(ed25519.PublicKey), pae, sig))
+		case *ecdsa.PrivateKey:
+			a.Len(envelope.Signatures, 1)
+			sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			a.Nil(err)
+			digest := sha256.Sum256(pae)
+			a.True(ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+		}
+
+		// cleanup
+		for k := range env {
+			if err := os.Unsetenv(k); err != nil {
+				t.Errorf("error cleanup: error unsetting environmental variable %v: %v", k, err)
+			}
+		}
+	}
+}
+
+func TestParseSigningKey(t *testing.T) {
+	a := assert.New(t)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating key: %v", err)
+	}
+
+	type test struct {
+		Key   []byte
+		Error string
+	}
+
+	suite := map[string]test{
+		"Success": {
+			Key:   pemPrivateKey(t, edKey),
+			Error: "",
+		},
+		"Not PEM": {
+			Key:   []byte("key"),
+			Error: "error decoding signing key: PEM block not found",
+		},
+		"Unsupported Type": {
+			Key:   pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("key")}),
+			Error: "unsupported signing key type PUBLIC KEY",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		k, err := release.ParseSigningKey(test.Key)
+		if test.Error != "" || err != nil {
+			a.EqualError(err, test.Error)
+			a.Nil(k)
+		} else {
+			a.Equal(edKey, k)
+		}
+	}
+}
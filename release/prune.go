@@ -0,0 +1,176 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	PruneLineMajor string = "major"
+	PruneLineMinor string = "minor"
+)
+
+// PruneRules define which releases are deleted by 'prune' command, zero values disable a rule
+type PruneRules struct {
+	// KeepPerLine is a number of the most recent stable releases kept per release line
+	KeepPerLine int
+	// Line groups stable releases either by major or by minor version
+	Line string
+	// PreReleaseAge is an age of pre-releases deleted once a stable release of a higher version exists (for example '1.2.0' for '1.2.0-rc.1')
+	PreReleaseAge time.Duration
+	// DraftAge is an age of deleted draft releases
+	DraftAge time.Duration
+}
+
+// PruneCandidate is a release selected for deletion
+type PruneCandidate struct {
+	ID     int64
+	Tag    string
+	Draft  bool
+	Reason string
+}
+
+type versionedRelease struct {
+	release *github.RepositoryRelease
+	version string
+}
+
+// PruneCandidates returns releases matching retention 'rules'
+func PruneCandidates(releases []*github.RepositoryRelease, prefix string, scheme VersionScheme, rules *PruneRules, now time.Time) []PruneCandidate {
+	candidates := make([]PruneCandidate, 0)
+	selected := make(map[int64]bool)
+	add := func(rel *github.RepositoryRelease, reason string) {
+		if selected[rel.GetID()] {
+			return
+		}
+
+		selected[rel.GetID()] = true
+		candidates = append(candidates, PruneCandidate{
+			ID:     rel.GetID(),
+			Tag:    rel.GetTagName(),
+			Draft:  rel.GetDraft(),
+			Reason: reason,
+		})
+	}
+
+	stable := make([]versionedRelease, 0)
+	prereleases := make([]versionedRelease, 0)
+	for _, rel := range releases {
+		if rel.GetDraft() {
+			if rules.DraftAge > 0 && now.Sub(rel.GetCreatedAt().Time) > rules.DraftAge {
+				add(rel, fmt.Sprintf("draft older than %v day(s)", int(rules.DraftAge.Hours()/24)))
+			}
+			continue
+		}
+
+		ref, err := ParseTag(rel.GetTagName(), prefix, scheme)
+		if err != nil {
+			continue
+		}
+
+		if rel.GetPrerelease() {
+			prereleases = append(prereleases, versionedRelease{release: rel, version: ref.Version})
+		} else {
+			stable = append(stable, versionedRelease{release: rel, version: ref.Version})
+		}
+	}
+
+	if rules.PreReleaseAge > 0 {
+		for _, p := range prereleases {
+			if now.Sub(published(p.release)) <= rules.PreReleaseAge {
+				continue
+			}
+
+			for _, s := range stable {
+				if scheme.Compare(s.version, p.version) > 0 {
+					add(p.release, fmt.Sprintf("pre-release older than %v day(s) superseded by %v", int(rules.PreReleaseAge.Hours()/24), s.release.GetTagName()))
+					break
+				}
+			}
+		}
+	}
+
+	if rules.KeepPerLine > 0 {
+		// most recent versions first
+		sort.SliceStable(stable, func(i, j int) bool {
+			return scheme.Compare(stable[i].version, stable[j].version) > 0
+		})
+
+		kept := make(map[string]int)
+		for _, s := range stable {
+			line := releaseLine(s.version, rules.Line)
+			if kept[line] < rules.KeepPerLine {
+				kept[line]++
+				continue
+			}
+
+			add(s.release, fmt.Sprintf("beyond %v most recent release(s) of %v line", rules.KeepPerLine, line))
+		}
+	}
+
+	return candidates
+}
+
+// Prune deletes 'candidates' releases and optionally their tags, nothing is deleted on 'dryRun'
+func (r *Release) Prune(repoCli RepositoriesClient, gitCli GitClient, candidates []PruneCandidate, deleteTags, dryRun bool) error {
+	for _, c := range candidates {
+		if dryRun {
+			log.Infof("[dry-run] would delete release %v: %v", c.Tag, c.Reason)
+			continue
+		}
+
+		log.Infof("deleting release %v: %v", c.Tag, c.Reason)
+		_, err := repoCli.DeleteRelease(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			c.ID,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error deleting release %v", c.Tag)
+		}
+
+		// tags of draft releases are not created until they are published
+		if !deleteTags || c.Draft || c.Tag == "" {
+			continue
+		}
+
+		_, err = gitCli.DeleteRef(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			fmt.Sprintf("refs/tags/%v", c.Tag),
+		)
+		if err != nil && !strings.Contains(err.Error(), "422 Reference does not exist") {
+			return errors.Wrapf(err, "error deleting tag %v", c.Tag)
+		}
+	}
+
+	return nil
+}
+
+// releaseLine returns a major ('1') or a major.minor ('1.2') part of a version
+func releaseLine(version, line string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if line == PruneLineMajor || len(parts) < 2 {
+		return parts[0]
+	}
+
+	return strings.Join(parts[:2], ".")
+}
+
+// published returns a publication date of a release, falling back to its creation date
+func published(rel *github.RepositoryRelease) time.Time {
+	if rel.PublishedAt != nil {
+		return rel.GetPublishedAt().Time
+	}
+
+	return rel.GetCreatedAt().Time
+}
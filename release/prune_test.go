@@ -0,0 +1,191 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneCandidates(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Date(2024, 3, 20, 15, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	timestamp := func(age time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: now.Add(-age)}
+	}
+
+	releases := []*github.RepositoryRelease{
+		{ID: int64P(1), TagName: stringP("v2.0.0"), PublishedAt: timestamp(1 * day)},
+		{ID: int64P(2), TagName: stringP("v1.2.1"), PublishedAt: timestamp(10 * day)},
+		{ID: int64P(3), TagName: stringP("v1.2.0"), PublishedAt: timestamp(20 * day)},
+		{ID: int64P(4), TagName: stringP("v1.1.1"), PublishedAt: timestamp(30 * day)},
+		{ID: int64P(5), TagName: stringP("v1.1.0"), PublishedAt: timestamp(40 * day)},
+		{ID: int64P(6), TagName: stringP("v1.2.0-rc.1"), Prerelease: boolP(true), PublishedAt: timestamp(25 * day)},
+		{ID: int64P(7), TagName: stringP("v2.1.0-rc.1"), Prerelease: boolP(true), PublishedAt: timestamp(25 * day)},
+		{ID: int64P(8), TagName: stringP("v2.0.0-rc.1"), Prerelease: boolP(true), PublishedAt: timestamp(2 * day)},
+		{ID: int64P(9), TagName: stringP(""), Draft: boolP(true), CreatedAt: timestamp(60 * day)},
+		{ID: int64P(10), TagName: stringP("v2.1.0"), Draft: boolP(true), CreatedAt: timestamp(5 * day)},
+		{ID: int64P(11), TagName: stringP("latest"), PublishedAt: timestamp(90 * day)},
+	}
+
+	type test struct {
+		Rules    release.PruneRules
+		Expected []release.PruneCandidate
+	}
+
+	suite := map[string]test{
+		"Keep Per Minor Line": {
+			Rules: release.PruneRules{KeepPerLine: 1, Line: release.PruneLineMinor},
+			Expected: []release.PruneCandidate{
+				{ID: 3, Tag: "v1.2.0", Reason: "beyond 1 most recent release(s) of 1.2 line"},
+				{ID: 5, Tag: "v1.1.0", Reason: "beyond 1 most recent release(s) of 1.1 line"},
+			},
+		},
+		"Keep Per Major Line": {
+			Rules: release.PruneRules{KeepPerLine: 2, Line: release.PruneLineMajor},
+			Expected: []release.PruneCandidate{
+				{ID: 4, Tag: "v1.1.1", Reason: "beyond 2 most recent release(s) of 1 line"},
+				{ID: 5, Tag: "v1.1.0", Reason: "beyond 2 most recent release(s) of 1 line"},
+			},
+		},
+		"Superseded Pre-Releases": {
+			Rules: release.PruneRules{PreReleaseAge: 7 * day},
+			Expected: []release.PruneCandidate{
+				{ID: 6, Tag: "v1.2.0-rc.1", Reason: "pre-release older than 7 day(s) superseded by v2.0.0"},
+			},
+		},
+		"Old Drafts": {
+			Rules: release.PruneRules{DraftAge: 30 * day},
+			Expected: []release.PruneCandidate{
+				{ID: 9, Tag: "", Draft: true, Reason: "draft older than 30 day(s)"},
+			},
+		},
+		"All Rules": {
+			Rules: release.PruneRules{KeepPerLine: 1, Line: release.PruneLineMajor, PreReleaseAge: 7 * day, DraftAge: 30 * day},
+			Expected: []release.PruneCandidate{
+				{ID: 9, Tag: "", Draft: true, Reason: "draft older than 30 day(s)"},
+				{ID: 6, Tag: "v1.2.0-rc.1", Reason: "pre-release older than 7 day(s) superseded by v2.0.0"},
+				{ID: 3, Tag: "v1.2.0", Reason: "beyond 1 most recent release(s) of 1 line"},
+				{ID: 4, Tag: "v1.1.1", Reason: "beyond 1 most recent release(s) of 1 line"},
+				{ID: 5, Tag: "v1.1.0", Reason: "beyond 1 most recent release(s) of 1 line"},
+			},
+		},
+		"Nothing to Prune": {
+			Rules:    release.PruneRules{KeepPerLine: 5, Line: release.PruneLineMinor},
+			Expected: []release.PruneCandidate{},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// test
+		candidates := release.PruneCandidates(releases, "v", release.SemVer{}, &test.Rules, now)
+		a.Equal(test.Expected, candidates)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	candidates := []release.PruneCandidate{
+		{ID: 1, Tag: "v1.0.0", Reason: "reason"},
+		{ID: 2, Tag: "v1.1.0", Draft: true, Reason: "reason"},
+	}
+
+	type test struct {
+		DeleteTags         bool
+		DryRun             bool
+		DeleteReleaseError error
+		DeleteRefError     error
+		ExpectedReleases   []int64
+		ExpectedTags       []string
+		ExpectedError      string
+	}
+
+	suite := map[string]test{
+		"Releases Only": {
+			ExpectedReleases: []int64{1, 2},
+		},
+		"Releases and Tags": {
+			DeleteTags:       true,
+			ExpectedReleases: []int64{1, 2},
+			ExpectedTags:     []string{"v1.0.0"},
+		},
+		"Missing Tag": {
+			DeleteTags:       true,
+			DeleteRefError:   errors.New("DELETE https://api.github.com/repos/anton-yurchenko/git-release/git/refs/tags/v1.0.0: 422 Reference does not exist []"),
+			ExpectedReleases: []int64{1, 2},
+			ExpectedTags:     []string{"v1.0.0"},
+		},
+		"Dry Run": {
+			DeleteTags: true,
+			DryRun:     true,
+		},
+		"Delete Release Error": {
+			DeleteReleaseError: errors.New("reason"),
+			ExpectedReleases:   []int64{1},
+			ExpectedError:      "error deleting release v1.0.0: reason",
+		},
+		"Delete Tag Error": {
+			DeleteTags:       true,
+			DeleteRefError:   errors.New("reason"),
+			ExpectedReleases: []int64{1},
+			ExpectedTags:     []string{"v1.0.0"},
+			ExpectedError:    "error deleting tag v1.0.0: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+		}
+
+		// test
+		repoMock := new(mocks.RepositoriesClient)
+		gitMock := new(mocks.GitClient)
+
+		for _, id := range test.ExpectedReleases {
+			repoMock.On("DeleteRelease",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				id).Return(nil, test.DeleteReleaseError).Once()
+		}
+
+		for _, tag := range test.ExpectedTags {
+			gitMock.On("DeleteRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"refs/tags/"+tag).Return(nil, test.DeleteRefError).Once()
+		}
+
+		err := rel.Prune(repoMock, gitMock, candidates, test.DeleteTags, test.DryRun)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		repoMock.AssertExpectations(t)
+		gitMock.AssertExpectations(t)
+	}
+}
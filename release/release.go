@@ -3,21 +3,23 @@ package release
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"strings"
 	"sync"
 	"time"
 
-	changelog "github.com/anton-yurchenko/go-changelog"
 	"github.com/google/go-github/github"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
 
-func GetRelease(fs afero.Fs, args []string, tagPrefix, name, namePrefix, nameSuffix string, unreleased bool) (*Release, error) {
-	release := new(Release)
+func GetRelease(fs afero.Fs, args []string, tagPrefix string, scheme VersionScheme, versionTag, name, namePrefix, nameSuffix string, unreleased bool) (*Release, error) {
+	release := &Release{
+		Scheme: scheme,
+	}
 
 	if strings.ToLower(os.Getenv("DRAFT_RELEASE")) == "true" {
 		release.Draft = true
@@ -33,7 +35,7 @@ func GetRelease(fs afero.Fs, args []string, tagPrefix, name, namePrefix, nameSuf
 		return nil, errors.Wrap(err, "error retrieving release assets")
 	}
 
-	release.Reference, err = GetReference(tagPrefix, unreleased)
+	release.Reference, err = GetReference(tagPrefix, scheme, versionTag, unreleased)
 	if err != nil {
 		return nil, errors.Wrap(err, "error retrieving source code reference (control tag prefix via env.var TAG_PREFIX_REGEX)")
 	}
@@ -54,8 +56,9 @@ func GetRelease(fs afero.Fs, args []string, tagPrefix, name, namePrefix, nameSuf
 	return release, nil
 }
 
-// GetReference loads a codebase references from workspace
-func GetReference(prefix string, unreleased bool) (*Reference, error) {
+// GetReference loads a codebase references from workspace.
+// 'versionTag' is a tag to be created at GITHUB_SHA when a workflow is not triggered by a tag.
+func GetReference(prefix string, scheme VersionScheme, versionTag string, unreleased bool) (*Reference, error) {
 	if os.Getenv("GITHUB_REF") == "" {
 		return nil, errors.New("GITHUB_REF is not defined")
 	} else if os.Getenv("GITHUB_REF") == UnreleasedRef {
@@ -80,20 +83,42 @@ func GetReference(prefix string, unreleased bool) (*Reference, error) {
 		}, nil
 	}
 
+	if versionTag != "" && !strings.HasPrefix(os.Getenv("GITHUB_REF"), "refs/tags/") {
+		ref, err := ParseTag(versionTag, prefix, scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		ref.CommitHash = os.Getenv("GITHUB_SHA")
+		if prefix == "" {
+			ref.Prefix = ""
+		}
+
+		return ref, nil
+	}
+
 	var expression string
 	if prefix != "" {
-		expression = fmt.Sprintf("^refs/tags/(?P<prefix>%v)%v$", prefix, changelog.SemVerRegex)
+		expression = fmt.Sprintf("^refs/tags/(?P<prefix>%v)%v$", prefix, scheme.Expression())
 	} else {
-		expression = fmt.Sprintf("^refs/tags/[v]?%v$", changelog.SemVerRegex)
+		expression = fmt.Sprintf("^refs/tags/[v]?%v$", scheme.Expression())
 	}
 	regex := regexp.MustCompile(expression)
 
 	if regex.MatchString(os.Getenv("GITHUB_REF")) {
-		var version string
-		if prefix != "" {
+		var version, p string
+		if i := versionGroupIndex(regex); i != -1 {
+			// version scheme defines its own version group
+			m := regex.FindStringSubmatch(os.Getenv("GITHUB_REF"))
+			version = m[i]
+			if prefix != "" {
+				p = m[regex.SubexpIndex("prefix")]
+			}
+		} else if prefix != "" {
 			versionRegex := regexp.MustCompile(fmt.Sprintf("^refs/tags/(?P<prefix>%v)(?P<version>.*)$", prefix))
 			if versionRegex.MatchString(os.Getenv("GITHUB_REF")) {
-				version = versionRegex.ReplaceAllString(os.Getenv("GITHUB_REF"), "${2}")
+				version = versionRegex.ReplaceAllString(os.Getenv("GITHUB_REF"), "${version}")
+				p = versionRegex.ReplaceAllString(os.Getenv("GITHUB_REF"), "${prefix}")
 			} else {
 				version = strings.TrimPrefix(os.Getenv("GITHUB_REF"), "refs/tags/")
 			}
@@ -105,12 +130,34 @@ func GetReference(prefix string, unreleased bool) (*Reference, error) {
 			CommitHash: os.Getenv("GITHUB_SHA"),
 			Tag:        strings.Join(strings.Split(os.Getenv("GITHUB_REF"), "/")[2:], "/"),
 			Version:    version,
+			Prefix:     p,
 		}, nil
 	}
 
 	return nil, errors.New(fmt.Sprintf("malformed env.var GITHUB_REF: expected to match regex '%v', got '%v'", expression, os.Getenv("GITHUB_REF")))
 }
 
+// ParseTag returns a reference of a version out of a 'tag' name
+func ParseTag(tag, prefix string, scheme VersionScheme) (*Reference, error) {
+	if prefix == "" {
+		prefix = "[v]?"
+	}
+
+	expression := fmt.Sprintf("^(?P<prefix>%v)(?P<version>%v)$", prefix, scheme.Expression())
+	regex := regexp.MustCompile(expression)
+
+	m := regex.FindStringSubmatch(tag)
+	if m == nil {
+		return nil, errors.New(fmt.Sprintf("malformed version: expected to match regex '%v', got '%v'", expression, tag))
+	}
+
+	return &Reference{
+		Tag:     tag,
+		Version: m[versionGroupIndex(regex)],
+		Prefix:  m[regex.SubexpIndex("prefix")],
+	}, nil
+}
+
 // GetSlug loads project information from a workspace
 func GetSlug() (*Slug, error) {
 	if os.Getenv("GITHUB_REPOSITORY") == "" {
@@ -130,57 +177,151 @@ func GetSlug() (*Slug, error) {
 	return nil, errors.New(fmt.Sprintf("malformed GITHUB_REPOSITORY (expected '%v', received '%v')", SlugRegex, i))
 }
 
-// Publish will create a GitHub release and upload assets to it
-func (r *Release) Publish(cli RepositoriesClient) error {
+// Publish will create a GitHub release and upload assets to it.
+// 'api' is used when the release sets 'make_latest' and to retrieve digests of already uploaded assets.
+func (r *Release) Publish(fs afero.Fs, cli RepositoriesClient, httpCli *http.Client, api APIClient) error {
+	rel := &github.RepositoryRelease{
+		Name:            &r.Name,
+		TagName:         &r.Reference.Tag,
+		TargetCommitish: &r.Reference.CommitHash,
+		Body:            &r.Changelog,
+		Draft:           &r.Draft,
+		Prerelease:      &r.PreRelease,
+	}
+
 	// create release
-	o, _, err := cli.CreateRelease(
-		context.Background(),
-		r.Slug.Owner,
-		r.Slug.Name,
-		&github.RepositoryRelease{
-			Name:            &r.Name,
-			TagName:         &r.Reference.Tag,
-			TargetCommitish: &r.Reference.CommitHash,
-			Body:            &r.Changelog,
-			Draft:           &r.Draft,
-			Prerelease:      &r.PreRelease,
-		},
-	)
+	var o *github.RepositoryRelease
+	var err error
+	if r.MakeLatest != "" {
+		o, err = r.createRelease(api, rel)
+	} else {
+		o, _, err = cli.CreateRelease(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			rel,
+		)
+	}
 	if err != nil {
-		return err
+		if !r.UpdateExisting || !strings.Contains(err.Error(), "already_exists") {
+			return err
+		}
+
+		log.Warnf("release with a tag %v already exists, updating its assets", r.Reference.Tag)
+		o, _, err = cli.GetReleaseByTag(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			r.Reference.Tag,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving an existing release with a tag %v", r.Reference.Tag)
+		}
+
+		if r.MakeLatest != "" {
+			if err := r.updateLatest(api, o.GetID()); err != nil {
+				return err
+			}
+		}
+	} else {
+		log.Info("release created successfully 🎉")
 	}
 
-	log.Info("release created successfully 🎉")
+	r.ID = o.GetID()
 
-	// upload assets
-	if r.Assets != nil {
-		errs := make(chan error, len(*r.Assets))
+	var uploaded []github.ReleaseAsset
+	if o != nil {
+		uploaded = o.Assets
+	}
 
-		wg := new(sync.WaitGroup)
-		wg.Add(len(*r.Assets))
+	return r.UploadAssets(fs, cli, httpCli, api, r.ID, uploaded)
+}
 
-		for _, a := range *r.Assets {
-			asset := a
-			go asset.Upload(r, cli, o.GetID(), errs, wg)
-		}
+// UploadAssets uploads release assets concurrently.
+// Assets identical to the already 'uploaded' ones are skipped, changed ones are replaced.
+// Content is compared by digests reported by 'api', 'httpCli' is used to follow download redirects
+// of release assets without a digest.
+func (r *Release) UploadAssets(fs afero.Fs, cli RepositoriesClient, httpCli *http.Client, api APIClient, id int64, uploaded []github.ReleaseAsset) error {
+	if r.Assets == nil {
+		return nil
+	}
 
-		var failure bool
-		for i := 0; i <= (len(*r.Assets) - 1); i++ {
-			err = <-errs
+	var digests map[string]string
+	assets := make([]Asset, 0)
+	for _, a := range *r.Assets {
+		remote := a.Find(uploaded)
+		if remote != nil {
+			if digests == nil {
+				var err error
+				digests, err = r.assetDigests(api, id)
+				if err != nil {
+					return err
+				}
+			}
 
+			identical, err := a.Identical(fs, r, cli, httpCli, remote, digests[remote.GetName()])
 			if err != nil {
-				failure = true
-				log.Error(err)
+				return err
+			}
+
+			if identical {
+				log.WithField("asset", a.Name).Info("asset already uploaded, skipping")
+				continue
+			}
+
+			log.WithField("asset", a.Name).Warn("asset changed, replacing")
+			_, err = cli.DeleteReleaseAsset(
+				context.Background(),
+				r.Slug.Owner,
+				r.Slug.Name,
+				remote.GetID(),
+			)
+			if err != nil {
+				return errors.Wrapf(err, "error deleting outdated release asset %v", a.Name)
 			}
 		}
 
-		wg.Wait()
+		assets = append(assets, a)
+	}
+
+	if len(assets) == 0 {
+		return nil
+	}
+
+	if err := r.uploadAssets(cli, id, assets); err != nil {
+		return err
+	}
+
+	log.Info("assets uploaded successfully 🎉")
+
+	return nil
+}
 
-		if failure {
-			return errors.New("error uploading assets")
+func (r *Release) uploadAssets(cli RepositoriesClient, id int64, assets []Asset) error {
+	errs := make(chan error, len(assets))
+
+	wg := new(sync.WaitGroup)
+	wg.Add(len(assets))
+
+	for _, a := range assets {
+		asset := a
+		go asset.Upload(r, cli, id, errs, wg)
+	}
+
+	var failure bool
+	for i := 0; i <= (len(assets) - 1); i++ {
+		err := <-errs
+
+		if err != nil {
+			failure = true
+			log.Error(err)
 		}
+	}
+
+	wg.Wait()
 
-		log.Info("assets uploaded successfully 🎉")
+	if failure {
+		return errors.New("error uploading assets")
 	}
 
 	return nil
@@ -265,3 +406,30 @@ func (r *Release) UpdateUnreleasedTag(gitCli GitClient) error {
 
 	return err
 }
+
+// ListReleases returns all releases of a repository
+func (r *Release) ListReleases(cli RepositoriesClient) ([]*github.RepositoryRelease, error) {
+	releases := make([]*github.RepositoryRelease, 0)
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		page, res, err := cli.ListReleases(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			opt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing releases")
+		}
+
+		releases = append(releases, page...)
+
+		if res == nil || res.NextPage == 0 {
+			break
+		}
+		opt.Page = res.NextPage
+	}
+
+	return releases, nil
+}
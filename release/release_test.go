@@ -2,8 +2,10 @@ package release_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -31,6 +33,14 @@ func int64P(n int64) *int64 {
 	return &n
 }
 
+func intP(n int) *int {
+	return &n
+}
+
+func boolP(b bool) *bool {
+	return &b
+}
+
 func TestGetSlug(t *testing.T) {
 	a := assert.New(t)
 
@@ -112,6 +122,7 @@ func TestGetReference(t *testing.T) {
 		GitHubSha     string
 		UnreleasedTag string
 		Prefix        string
+		VersionTag    string
 		Unreleased    bool
 		Expected      expected
 	}
@@ -180,6 +191,7 @@ func TestGetReference(t *testing.T) {
 					CommitHash: "111",
 					Version:    "1.0.0",
 					Tag:        "a1.0.0",
+					Prefix:     "a",
 				},
 				Error: "",
 			},
@@ -195,6 +207,7 @@ func TestGetReference(t *testing.T) {
 					CommitHash: "111",
 					Version:    "1.0.0",
 					Tag:        "prerelease-1.0.0",
+					Prefix:     "prerelease-",
 				},
 				Error: "",
 			},
@@ -258,6 +271,7 @@ func TestGetReference(t *testing.T) {
 					CommitHash: "111",
 					Version:    "1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
 					Tag:        "1.0.01.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+					Prefix:     "1.0.0",
 				},
 				Error: "",
 			},
@@ -288,6 +302,56 @@ func TestGetReference(t *testing.T) {
 				Error: "",
 			},
 		},
+		"Version Tag on Branch": {
+			GitHubRef:  "refs/heads/master",
+			GitHubSha:  "111",
+			VersionTag: "v1.2.0",
+			Expected: expected{
+				Result: &release.Reference{
+					CommitHash: "111",
+					Version:    "1.2.0",
+					Tag:        "v1.2.0",
+				},
+				Error: "",
+			},
+		},
+		"Version Tag with custom Prefix": {
+			GitHubRef:  "refs/heads/master",
+			GitHubSha:  "111",
+			Prefix:     "component-",
+			VersionTag: "component-1.2.0",
+			Expected: expected{
+				Result: &release.Reference{
+					CommitHash: "111",
+					Version:    "1.2.0",
+					Tag:        "component-1.2.0",
+					Prefix:     "component-",
+				},
+				Error: "",
+			},
+		},
+		"Version Tag ignored on Tag": {
+			GitHubRef:  "refs/tags/v1.0.0",
+			GitHubSha:  "111",
+			VersionTag: "v1.2.0",
+			Expected: expected{
+				Result: &release.Reference{
+					CommitHash: "111",
+					Version:    "1.0.0",
+					Tag:        "v1.0.0",
+				},
+				Error: "",
+			},
+		},
+		"Malformed Version Tag": {
+			GitHubRef:  "refs/heads/master",
+			GitHubSha:  "111",
+			VersionTag: "v1.2",
+			Expected: expected{
+				Result: nil,
+				Error:  fmt.Sprintf("malformed version: expected to match regex '^(?P<prefix>[v]?)(?P<version>%v)$', got 'v1.2'", changelog.SemVerRegex),
+			},
+		},
 		"Unreleased with custom Tag": {
 			GitHubRef:     "refs/heads/master",
 			GitHubSha:     "111",
@@ -326,7 +390,7 @@ func TestGetReference(t *testing.T) {
 		time.Sleep(30 * time.Millisecond)
 
 		// test
-		r, err := release.GetReference(test.Prefix, test.Unreleased)
+		r, err := release.GetReference(test.Prefix, release.SemVer{}, test.VersionTag, test.Unreleased)
 		a.Equal(test.Expected.Result, r)
 		if test.Expected.Error != "" || err != nil {
 			a.EqualError(err, test.Expected.Error)
@@ -349,6 +413,60 @@ func TestGetReference(t *testing.T) {
 	}
 }
 
+func TestParseTag(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Tag               string
+		Prefix            string
+		ExpectedReference *release.Reference
+		ExpectedError     string
+	}
+
+	suite := map[string]test{
+		"Version": {
+			Tag: "1.2.0",
+			ExpectedReference: &release.Reference{
+				Tag:     "1.2.0",
+				Version: "1.2.0",
+			},
+		},
+		"Version with Prefix": {
+			Tag: "v1.2.0-rc.1",
+			ExpectedReference: &release.Reference{
+				Tag:     "v1.2.0-rc.1",
+				Version: "1.2.0-rc.1",
+				Prefix:  "v",
+			},
+		},
+		"Version with Custom Prefix": {
+			Tag:    "release-1.2.0",
+			Prefix: "release-",
+			ExpectedReference: &release.Reference{
+				Tag:     "release-1.2.0",
+				Version: "1.2.0",
+				Prefix:  "release-",
+			},
+		},
+		"Malformed Version": {
+			Tag:           "1.2",
+			ExpectedError: "malformed version: expected to match regex",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		ref, err := release.ParseTag(test.Tag, test.Prefix, release.SemVer{})
+		if test.ExpectedError != "" || err != nil {
+			a.ErrorContains(err, test.ExpectedError)
+		}
+		a.Equal(test.ExpectedReference, ref)
+	}
+}
+
 func TestGetRelease(t *testing.T) {
 	a := assert.New(t)
 	roBase := afero.NewReadOnlyFs(afero.NewOsFs())
@@ -401,6 +519,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: false,
+					Scheme:     release.SemVer{},
 					Assets: &[]release.Asset{
 						{
 							Name: "file1",
@@ -438,9 +557,11 @@ func TestGetRelease(t *testing.T) {
 						CommitHash: "111",
 						Tag:        "abc1.0.0",
 						Version:    "1.0.0",
+						Prefix:     "abc",
 					},
 					Draft:      false,
 					PreRelease: false,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -472,6 +593,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      true,
 					PreRelease: false,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -503,6 +625,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: true,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -568,6 +691,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: false,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -599,6 +723,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: false,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -630,6 +755,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: false,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -661,6 +787,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: false,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -692,6 +819,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: true,
+					Scheme:     release.SemVer{},
 					Assets: &[]release.Asset{
 						{
 							Name: "file1",
@@ -732,6 +860,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: true,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -763,6 +892,7 @@ func TestGetRelease(t *testing.T) {
 					},
 					Draft:      false,
 					PreRelease: true,
+					Scheme:     release.SemVer{},
 					Assets:     &[]release.Asset{},
 				},
 				Error: "",
@@ -806,7 +936,7 @@ func TestGetRelease(t *testing.T) {
 		time.Sleep(30 * time.Millisecond)
 
 		// test
-		r, err := release.GetRelease(fs, test.Files, test.TagPrefix, test.Name, test.NamePrefix, test.NameSuffix, test.Unreleased)
+		r, err := release.GetRelease(fs, test.Files, test.TagPrefix, release.SemVer{}, "", test.Name, test.NamePrefix, test.NameSuffix, test.Unreleased)
 		a.Equal(test.Expected.Result, r)
 		if test.Expected.Error != "" || err != nil {
 			a.EqualError(err, test.Expected.Error)
@@ -853,9 +983,15 @@ func TestPublish(t *testing.T) {
 		Error  error
 	}
 
+	type getReleaseByTagMock struct {
+		Output *github.RepositoryRelease
+		Error  error
+	}
+
 	type test struct {
 		Release                *release.Release
 		CreateReleaseMock      createReleaseMock
+		GetReleaseByTagMock    *getReleaseByTagMock
 		UploadReleaseAssetMock []error
 		ExpectedError          string
 		FailedAttempts         int
@@ -975,6 +1111,93 @@ func TestPublish(t *testing.T) {
 			UploadReleaseAssetMock: []error{errors.New("reason")},
 			ExpectedError:          "error uploading assets",
 		},
+		"Existing Release": {
+			Release: &release.Release{
+				Name: "1.0.0",
+				Slug: &release.Slug{
+					Owner: "anton-yurchenko",
+					Name:  "git-release",
+				},
+				Reference: &release.Reference{
+					CommitHash: "111",
+					Tag:        "1.0.0",
+					Version:    "1.0.0",
+				},
+				Draft:      false,
+				PreRelease: false,
+				Assets: &[]release.Asset{
+					{
+						Name: "file1",
+						Path: "file1",
+					},
+				},
+				Changelog:      "changelog",
+				UpdateExisting: true,
+			},
+			CreateReleaseMock: createReleaseMock{
+				Output: nil,
+				Error:  errors.New("422 Validation Failed [{Resource:Release Field:tag_name Code:already_exists Message:}]"),
+			},
+			GetReleaseByTagMock: &getReleaseByTagMock{
+				Output: &github.RepositoryRelease{
+					ID: int64P(3),
+				},
+				Error: nil,
+			},
+			UploadReleaseAssetMock: []error{nil},
+			ExpectedError:          "",
+		},
+		"Existing Release without Update": {
+			Release: &release.Release{
+				Name: "1.0.0",
+				Slug: &release.Slug{
+					Owner: "anton-yurchenko",
+					Name:  "git-release",
+				},
+				Reference: &release.Reference{
+					CommitHash: "111",
+					Tag:        "1.0.0",
+					Version:    "1.0.0",
+				},
+				Draft:      false,
+				PreRelease: false,
+				Assets:     nil,
+				Changelog:  "changelog",
+			},
+			CreateReleaseMock: createReleaseMock{
+				Output: nil,
+				Error:  errors.New("422 Validation Failed [{Resource:Release Field:tag_name Code:already_exists Message:}]"),
+			},
+			ExpectedError: "422 Validation Failed [{Resource:Release Field:tag_name Code:already_exists Message:}]",
+		},
+		"Error Retrieving Existing Release": {
+			Release: &release.Release{
+				Name: "1.0.0",
+				Slug: &release.Slug{
+					Owner: "anton-yurchenko",
+					Name:  "git-release",
+				},
+				Reference: &release.Reference{
+					CommitHash: "111",
+					Tag:        "1.0.0",
+					Version:    "1.0.0",
+				},
+				Draft:          false,
+				PreRelease:     false,
+				Assets:         nil,
+				Changelog:      "changelog",
+				UpdateExisting: true,
+			},
+			CreateReleaseMock: createReleaseMock{
+				Output: nil,
+				Error:  errors.New("422 Validation Failed [{Resource:Release Field:tag_name Code:already_exists Message:}]"),
+			},
+			GetReleaseByTagMock: &getReleaseByTagMock{
+				Output: nil,
+				Error:  errors.New("reason"),
+			},
+			ExpectedError: "error retrieving an existing release with a tag 1.0.0: reason",
+		},
 	}
 
 	var counter int
@@ -1012,6 +1235,14 @@ main:
 				Prerelease:      &test.Release.PreRelease,
 			}).Return(test.CreateReleaseMock.Output, nil, test.CreateReleaseMock.Error).Once()
 
+		if test.GetReleaseByTagMock != nil {
+			m.On("GetReleaseByTag",
+				context.Background(),
+				test.Release.Slug.Owner,
+				test.Release.Slug.Name,
+				test.Release.Reference.Tag).Return(test.GetReleaseByTagMock.Output, nil, test.GetReleaseByTagMock.Error).Once()
+		}
+
 		if test.Release.Assets != nil {
 			for i, asset := range *test.Release.Assets {
 				m.On("UploadReleaseAsset",
@@ -1021,6 +1252,8 @@ main:
 					func() int64 {
 						if test.CreateReleaseMock.Output != nil {
 							return *test.CreateReleaseMock.Output.ID
+						} else if test.GetReleaseByTagMock != nil && test.GetReleaseByTagMock.Output != nil {
+							return *test.GetReleaseByTagMock.Output.ID
 						} else {
 							return int64(0)
 						}
@@ -1032,7 +1265,7 @@ main:
 			}
 		}
 
-		err := test.Release.Publish(m)
+		err := test.Release.Publish(fs, m, nil, nil)
 		if test.ExpectedError != "" || err != nil {
 			a.EqualError(err, test.ExpectedError)
 		}
@@ -1052,6 +1285,256 @@ main:
 	}
 }
 
+func TestUploadAssets(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+	fs := afero.NewOsFs()
+	id := int64(1)
+
+	type test struct {
+		Uploaded               []github.ReleaseAsset
+		Digests                string
+		DigestsError           error
+		DownloadedContent      *string
+		DownloadError          error
+		DeleteReleaseAssetMock *error
+		UploadReleaseAssetMock *error
+		ExpectedError          string
+	}
+
+	rel := &release.Release{
+		Slug: &release.Slug{
+			Owner: "anton-yurchenko",
+			Name:  "git-release",
+		},
+		Reference: &release.Reference{
+			Tag: "1.0.0",
+		},
+		Assets: &[]release.Asset{
+			{
+				Name: "test/File1",
+				Path: "testFile1",
+			},
+		},
+	}
+
+	noError := error(nil)
+	deleteError := errors.New("reason")
+	content := "content"
+	changedContent := "changed"
+
+	suite := map[string]test{
+		"New Asset": {
+			Uploaded:               []github.ReleaseAsset{},
+			UploadReleaseAssetMock: &noError,
+			ExpectedError:          "",
+		},
+		"Identical Asset": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(7),
+				},
+			},
+			Digests:           `[]`,
+			DownloadedContent: &content,
+			ExpectedError:     "",
+		},
+		"Identical Asset by Digest": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(7),
+				},
+			},
+			Digests:       `[{"name": "test-File1", "digest": "sha256:ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"}]`,
+			ExpectedError: "",
+		},
+		"Changed Asset by Digest": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(7),
+				},
+			},
+			Digests:                `[{"name": "test-File1", "digest": "sha256:d67e2e944994496c8d8ec76eed0cf9f09679448d584b532bebf941852a37f5ed"}]`,
+			DeleteReleaseAssetMock: &noError,
+			UploadReleaseAssetMock: &noError,
+			ExpectedError:          "",
+		},
+		"Error Retrieving Digests": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(7),
+				},
+			},
+			DigestsError:  errors.New("reason"),
+			ExpectedError: "error retrieving release assets digests: reason",
+		},
+		"Changed Asset of the Same Size": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(7),
+				},
+			},
+			Digests:                `[]`,
+			DownloadedContent:      &changedContent,
+			DeleteReleaseAssetMock: &noError,
+			UploadReleaseAssetMock: &noError,
+			ExpectedError:          "",
+		},
+		"Error Downloading Uploaded Asset": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(7),
+				},
+			},
+			Digests:           `[]`,
+			DownloadedContent: stringP(""),
+			DownloadError:     errors.New("reason"),
+			ExpectedError:     "error downloading release asset test/File1: reason",
+		},
+		"Changed Asset": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(8),
+				},
+			},
+			Digests:                `[]`,
+			DeleteReleaseAssetMock: &noError,
+			UploadReleaseAssetMock: &noError,
+			ExpectedError:          "",
+		},
+		"Partially Uploaded Asset": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("starter"),
+					Size:  intP(7),
+				},
+			},
+			Digests:                `[]`,
+			DeleteReleaseAssetMock: &noError,
+			UploadReleaseAssetMock: &noError,
+			ExpectedError:          "",
+		},
+		"Error Deleting Changed Asset": {
+			Uploaded: []github.ReleaseAsset{
+				{
+					ID:    int64P(2),
+					Name:  stringP("test-File1"),
+					State: stringP("uploaded"),
+					Size:  intP(8),
+				},
+			},
+			Digests:                `[]`,
+			DeleteReleaseAssetMock: &deleteError,
+			ExpectedError:          "error deleting outdated release asset test/File1: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		if err := afero.WriteFile(fs, "testFile1", []byte("content"), 0644); err != nil {
+			t.Errorf("error preparing test case: error creating file testFile1: %v", err)
+			continue
+		}
+		time.Sleep(30 * time.Millisecond)
+
+		// test
+		m := new(mocks.RepositoriesClient)
+		apiMock := new(mocks.APIClient)
+
+		if test.Digests != "" || test.DigestsError != nil {
+			req := new(http.Request)
+			apiMock.On("NewRequest",
+				"GET",
+				"repos/anton-yurchenko/git-release/releases/1/assets?per_page=100&page=1",
+				nil).Return(req, nil).Once()
+
+			apiMock.On("Do",
+				context.Background(),
+				req,
+				mock.Anything).Return(&github.Response{}, test.DigestsError).Run(func(args mock.Arguments) {
+				if test.DigestsError == nil {
+					if err := json.Unmarshal([]byte(test.Digests), args.Get(2)); err != nil {
+						t.Errorf("error preparing test case: error decoding digests: %v", err)
+					}
+				}
+			}).Once()
+		}
+
+		if test.DownloadedContent != nil {
+			var rc io.ReadCloser
+			if test.DownloadError == nil {
+				rc = io.NopCloser(strings.NewReader(*test.DownloadedContent))
+			}
+
+			m.On("DownloadReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(2)).Return(rc, "", test.DownloadError).Once()
+		}
+
+		if test.DeleteReleaseAssetMock != nil {
+			m.On("DeleteReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(2)).Return(nil, *test.DeleteReleaseAssetMock).Once()
+		}
+
+		if test.UploadReleaseAssetMock != nil {
+			m.On("UploadReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				id,
+				&github.UploadOptions{
+					Name: "test-File1",
+				},
+				mock.AnythingOfType("*os.File")).Return(nil, nil, *test.UploadReleaseAssetMock).Once()
+		}
+
+		err := rel.UploadAssets(fs, m, nil, apiMock, id, test.Uploaded)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+		apiMock.AssertExpectations(t)
+
+		// cleanup
+		if err := fs.Remove("testFile1"); err != nil {
+			t.Errorf("error cleanup: error removing file testFile1: %v", err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+}
+
 func TestDeleteUnreleased(t *testing.T) {
 	a := assert.New(t)
 	log.SetOutput(io.Discard)
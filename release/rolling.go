@@ -0,0 +1,151 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// PublishRolling updates an Unreleased release in place: the tag is force-moved to the current commit,
+// the release is edited and its assets are swapped, so that release ID, URL and download counts survive.
+// The release is created when it does not exist yet.
+func (r *Release) PublishRolling(fs afero.Fs, repoCli RepositoriesClient, gitCli GitClient, httpCli *http.Client, api APIClient) error {
+	if err := r.MoveTag(gitCli); err != nil {
+		return errors.Wrapf(err, "error moving %v tag", r.Reference.Tag)
+	}
+
+	previous, err := r.precedentRelease(repoCli)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving a precedent release with a tag %v", r.Reference.Tag)
+	}
+
+	if previous == nil {
+		log.Warn("precedent release not found")
+		return r.Publish(fs, repoCli, httpCli, api)
+	}
+
+	o, _, err := repoCli.EditRelease(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		previous.GetID(),
+		&github.RepositoryRelease{
+			Name:            &r.Name,
+			TargetCommitish: &r.Reference.CommitHash,
+			Body:            &r.Changelog,
+			Draft:           &r.Draft,
+			Prerelease:      &r.PreRelease,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "error updating precedent release")
+	}
+	log.Info("release updated successfully 🎉")
+
+	r.ID = previous.GetID()
+
+	if r.MakeLatest != "" {
+		if err := r.updateLatest(api, r.ID); err != nil {
+			return err
+		}
+	}
+
+	return r.SwapAssets(fs, repoCli, httpCli, api, o.Assets)
+}
+
+// precedentRelease returns a release with the same tag or nil when it does not exist.
+// Draft releases are not retrievable by a tag, so they are looked up among all releases.
+func (r *Release) precedentRelease(cli RepositoriesClient) (*github.RepositoryRelease, error) {
+	if r.Draft {
+		releases, err := r.ListReleases(cli)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rel := range releases {
+			if rel.GetTagName() == r.Reference.Tag {
+				return rel, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	previous, _, err := cli.GetReleaseByTag(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		r.Reference.Tag,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// MoveTag force-moves the release tag to the current commit, the tag is created when it does not exist
+func (r *Release) MoveTag(gitCli GitClient) error {
+	tag := fmt.Sprintf("refs/tags/%v", r.Reference.Tag)
+
+	_, _, err := gitCli.UpdateRef(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		&github.Reference{
+			Ref: &tag,
+			Object: &github.GitObject{
+				SHA: &r.Reference.CommitHash,
+			},
+		},
+		true,
+	)
+	if err != nil && strings.Contains(err.Error(), "Reference does not exist") {
+		log.Warn("precedent tag not found")
+		return r.UpdateUnreleasedTag(gitCli)
+	}
+
+	return err
+}
+
+// SwapAssets deletes 'uploaded' release assets that no longer exist and uploads the current ones,
+// identical assets are kept and changed ones are replaced
+func (r *Release) SwapAssets(fs afero.Fs, cli RepositoriesClient, httpCli *http.Client, api APIClient, uploaded []github.ReleaseAsset) error {
+	for _, remote := range uploaded {
+		var current bool
+		if r.Assets != nil {
+			for _, a := range *r.Assets {
+				if a.uploadName() == remote.GetName() {
+					current = true
+					break
+				}
+			}
+		}
+
+		if current {
+			continue
+		}
+
+		_, err := cli.DeleteReleaseAsset(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			remote.GetID(),
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error deleting stale release asset %v", remote.GetName())
+		}
+	}
+
+	return r.UploadAssets(fs, cli, httpCli, api, r.ID, uploaded)
+}
@@ -0,0 +1,236 @@
+package release_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublishRolling(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	dir := t.TempDir()
+	asset := filepath.Join(dir, "linux.zip")
+	if err := os.WriteFile(asset, []byte("content"), 0644); err != nil {
+		t.Fatalf("error preparing test case: error creating file %v: %v", asset, err)
+	}
+
+	type test struct {
+		Draft                    bool
+		UploadedContent          string
+		UploadedDigest           string
+		UpdateRefMockError       error
+		GetReleaseByTagMockError error
+		EditReleaseMockError     error
+		ExpectedError            string
+	}
+
+	suite := map[string]test{
+		"Update in Place": {
+			UploadedContent: "changed",
+		},
+		"Update in Place with Identical Asset": {
+			UploadedContent: "content",
+		},
+		"Update in Place with Identical Asset Digest": {
+			UploadedContent: "content",
+			UploadedDigest:  "sha256:ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+		},
+		"Update Draft in Place": {
+			Draft:           true,
+			UploadedContent: "changed",
+		},
+		"Missing Tag": {
+			UpdateRefMockError: errors.New("422 Reference does not exist"),
+		},
+		"Missing Release": {
+			GetReleaseByTagMockError: errors.New("404 Not Found"),
+		},
+		"Tag Error": {
+			UpdateRefMockError: errors.New("reason"),
+			ExpectedError:      "error moving latest tag: reason",
+		},
+		"Edit Error": {
+			EditReleaseMockError: errors.New("reason"),
+			ExpectedError:        "error updating precedent release: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Name: "latest",
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "222",
+				Tag:        "latest",
+				Version:    "Unreleased",
+			},
+			Draft:      test.Draft,
+			PreRelease: true,
+			Changelog:  "changelog",
+			Assets: &[]release.Asset{
+				{
+					Name: "linux.zip",
+					Path: asset,
+				},
+			},
+		}
+
+		// test
+		repoMock := new(mocks.RepositoriesClient)
+		gitMock := new(mocks.GitClient)
+		apiMock := new(mocks.APIClient)
+
+		gitMock.On("UpdateRef",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name,
+			&github.Reference{
+				Ref:    stringP("refs/tags/latest"),
+				Object: &github.GitObject{SHA: stringP("222")},
+			},
+			true).Return(nil, nil, test.UpdateRefMockError).Once()
+
+		if test.UpdateRefMockError != nil && test.ExpectedError == "" {
+			gitMock.On("CreateRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.Reference{
+					Ref:    stringP("refs/tags/latest"),
+					Object: &github.GitObject{SHA: stringP("222")},
+				}).Return(nil, nil, nil).Once()
+		}
+
+		if test.Draft {
+			repoMock.On("ListReleases",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.ListOptions{PerPage: 100}).Return([]*github.RepositoryRelease{
+				{ID: int64P(3), TagName: stringP("v1.0.0")},
+				{ID: int64P(1), TagName: stringP("latest"), Draft: boolP(true)},
+			}, &github.Response{}, nil).Once()
+		} else if test.ExpectedError == "" || test.EditReleaseMockError != nil {
+			repoMock.On("GetReleaseByTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"latest").Return(&github.RepositoryRelease{ID: int64P(1)}, nil, test.GetReleaseByTagMockError).Once()
+		}
+
+		if test.GetReleaseByTagMockError != nil {
+			repoMock.On("CreateRelease",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				mock.AnythingOfType("*github.RepositoryRelease")).Return(&github.RepositoryRelease{ID: int64P(2)}, nil, nil).Once()
+		} else if test.ExpectedError == "" || test.EditReleaseMockError != nil {
+			repoMock.On("EditRelease",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(1),
+				&github.RepositoryRelease{
+					Name:            stringP("latest"),
+					TargetCommitish: stringP("222"),
+					Body:            stringP("changelog"),
+					Draft:           boolP(test.Draft),
+					Prerelease:      boolP(true),
+				}).Return(&github.RepositoryRelease{
+				ID: int64P(1),
+				Assets: []github.ReleaseAsset{
+					{ID: int64P(10), Name: stringP("linux.zip"), State: stringP("uploaded"), Size: intP(7)},
+					{ID: int64P(11), Name: stringP("darwin.zip"), State: stringP("uploaded"), Size: intP(7)},
+				},
+			}, nil, test.EditReleaseMockError).Once()
+
+			if test.EditReleaseMockError == nil {
+				repoMock.On("DeleteReleaseAsset",
+					context.Background(),
+					rel.Slug.Owner,
+					rel.Slug.Name,
+					int64(11)).Return(nil, nil).Once()
+
+				req := new(http.Request)
+				apiMock.On("NewRequest",
+					"GET",
+					"repos/anton-yurchenko/git-release/releases/1/assets?per_page=100&page=1",
+					nil).Return(req, nil).Once()
+
+				apiMock.On("Do",
+					context.Background(),
+					req,
+					mock.Anything).Return(&github.Response{}, nil).Run(func(args mock.Arguments) {
+					digests := fmt.Sprintf(`[{"name": "linux.zip", "digest": "%v"}]`, test.UploadedDigest)
+					if err := json.Unmarshal([]byte(digests), args.Get(2)); err != nil {
+						t.Errorf("error preparing test case: error decoding digests: %v", err)
+					}
+				}).Once()
+
+				if test.UploadedDigest == "" {
+					repoMock.On("DownloadReleaseAsset",
+						context.Background(),
+						rel.Slug.Owner,
+						rel.Slug.Name,
+						int64(10)).Return(io.NopCloser(strings.NewReader(test.UploadedContent)), "", nil).Once()
+				}
+
+				if test.UploadedContent != "content" {
+					repoMock.On("DeleteReleaseAsset",
+						context.Background(),
+						rel.Slug.Owner,
+						rel.Slug.Name,
+						int64(10)).Return(nil, nil).Once()
+				}
+			}
+		}
+
+		if test.ExpectedError == "" && test.UploadedContent != "content" {
+			repoMock.On("UploadReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				func() int64 {
+					if test.GetReleaseByTagMockError != nil {
+						return int64(2)
+					}
+					return int64(1)
+				}(),
+				&github.UploadOptions{Name: "linux.zip"},
+				mock.AnythingOfType("*os.File")).Return(nil, nil, nil).Once()
+		}
+
+		err := rel.PublishRolling(afero.NewOsFs(), repoMock, gitMock, nil, apiMock)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		repoMock.AssertExpectations(t)
+		gitMock.AssertExpectations(t)
+		apiMock.AssertExpectations(t)
+	}
+}
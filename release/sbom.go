@@ -0,0 +1,362 @@
+package release
+
+import (
+	"crypto/rand"
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const (
+	SBOMFormatCycloneDX string = "cyclonedx"
+	SBOMFormatSPDX      string = "spdx"
+	SBOMSuffix          string = ".sbom.json"
+)
+
+type cycloneDXDocument struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDXMetadata     `json:"metadata"`
+	Components   []cycloneDXComponent  `json:"components"`
+	Dependencies []cycloneDXDependency `json:"dependencies"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     cycloneDXTools     `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTools struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// GenerateSBOMs creates an SBOM for every Go binary among release assets and attaches it to the release
+func (r *Release) GenerateSBOMs(fs afero.Fs, format string) error {
+	if r.Assets == nil {
+		return nil
+	}
+
+	existing := make(map[string]bool)
+	for _, a := range *r.Assets {
+		existing[a.Name] = true
+	}
+
+	sboms := make([]Asset, 0)
+	for _, a := range *r.Assets {
+		// sbom supplied by a user (or generated by a previous run and matched by an asset pattern) is kept as is
+		if existing[a.Name+SBOMSuffix] {
+			log.WithField("asset", a.Name).Infof("skipping sbom generation: %v already exists", a.Name+SBOMSuffix)
+			continue
+		}
+
+		s, err := a.GenerateSBOM(fs, format)
+		if err != nil {
+			return errors.Wrapf(err, "error generating sbom for asset %v", a.Name)
+		}
+
+		if s != nil {
+			sboms = append(sboms, *s)
+		}
+	}
+
+	*r.Assets = append(*r.Assets, sboms...)
+
+	return nil
+}
+
+// GenerateSBOM writes an SBOM of a Go binary next to the asset.
+// A nil asset is returned when the asset is not a Go binary.
+func (a *Asset) GenerateSBOM(fs afero.Fs, format string) (*Asset, error) {
+	file, err := fs.Open(a.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening a file")
+	}
+
+	info, err := buildinfo.Read(file)
+	_ = file.Close()
+	if err != nil {
+		log.WithField("asset", a.Name).Debugf("skipping sbom generation: %v", err.Error())
+		return nil, nil
+	}
+
+	var document interface{}
+	switch format {
+	case SBOMFormatCycloneDX:
+		document, err = newCycloneDXDocument(a.Name, info)
+	case SBOMFormatSPDX:
+		document, err = newSPDXDocument(a.Name, info)
+	default:
+		return nil, errors.New(fmt.Sprintf("unsupported sbom format %v", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding sbom")
+	}
+
+	sbom := &Asset{
+		Name:      a.Name + SBOMSuffix,
+		Path:      a.Path + SBOMSuffix,
+		Generated: true,
+	}
+
+	if err := afero.WriteFile(fs, sbom.Path, b, 0644); err != nil {
+		return nil, errors.Wrap(err, "error writing sbom")
+	}
+
+	log.WithField("asset", a.Name).Infof("sbom generated: %v", sbom.Name)
+	return sbom, nil
+}
+
+func newCycloneDXDocument(name string, info *debug.BuildInfo) (*cycloneDXDocument, error) {
+	serial, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	application := moduleComponent("application", &info.Main)
+	if application.Name == "" {
+		application.Name = info.Path
+	}
+	if application.Name == "" {
+		application.Name = name
+	}
+	if application.BOMRef == "" {
+		application.BOMRef = application.Name
+	}
+	application.Properties = []cycloneDXProperty{
+		{
+			Name:  "go.version",
+			Value: info.GoVersion,
+		},
+	}
+
+	components := make([]cycloneDXComponent, 0)
+	refs := make([]string, 0)
+	for _, m := range info.Deps {
+		c := moduleComponent("library", m)
+		components = append(components, c)
+		refs = append(refs, c.BOMRef)
+	}
+
+	return &cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: fmt.Sprintf("urn:uuid:%v", serial),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools: cycloneDXTools{
+				Components: []cycloneDXComponent{
+					{
+						Type: "application",
+						Name: "git-release",
+					},
+				},
+			},
+			Component: application,
+		},
+		Components: components,
+		Dependencies: []cycloneDXDependency{
+			{
+				Ref:       application.BOMRef,
+				DependsOn: refs,
+			},
+		},
+	}, nil
+}
+
+func newSPDXDocument(name string, info *debug.BuildInfo) (*spdxDocument, error) {
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	modules := append([]*debug.Module{&info.Main}, info.Deps...)
+	packages := make([]spdxPackage, 0)
+	relationships := make([]spdxRelationship, 0)
+
+	for i, m := range modules {
+		local := localModule(m)
+		m = resolveModule(m)
+
+		p := spdxPackage{
+			Name:             m.Path,
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%v", i),
+			VersionInfo:      m.Version,
+			DownloadLocation: "NOASSERTION",
+			FilesAnalyzed:    false,
+		}
+		if i == 0 && p.Name == "" {
+			p.Name = info.Path
+		}
+		if m.Path != "" && !local {
+			p.ExternalRefs = []spdxExternalRef{
+				{
+					ReferenceCategory: "PACKAGE-MANAGER",
+					ReferenceType:     "purl",
+					ReferenceLocator:  modulePURL(m),
+				},
+			}
+		}
+		packages = append(packages, p)
+
+		if i == 0 {
+			relationships = append(relationships, spdxRelationship{
+				SPDXElementID:      "SPDXRef-DOCUMENT",
+				RelationshipType:   "DESCRIBES",
+				RelatedSPDXElement: p.SPDXID,
+			})
+		} else {
+			relationships = append(relationships, spdxRelationship{
+				SPDXElementID:      packages[0].SPDXID,
+				RelationshipType:   "DEPENDS_ON",
+				RelatedSPDXElement: p.SPDXID,
+			})
+		}
+	}
+
+	return &spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: fmt.Sprintf("https://spdx.org/spdxdocs/%v-%v", name, id),
+		CreationInfo: spdxCreationInfo{
+			Created:  time.Now().UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: git-release"},
+		},
+		Packages:      packages,
+		Relationships: relationships,
+	}, nil
+}
+
+func moduleComponent(kind string, m *debug.Module) cycloneDXComponent {
+	local := localModule(m)
+	m = resolveModule(m)
+
+	c := cycloneDXComponent{
+		Type:    kind,
+		Name:    m.Path,
+		Version: m.Version,
+	}
+	if m.Path != "" && !local {
+		c.PURL = modulePURL(m)
+		c.BOMRef = c.PURL
+	} else if m.Path != "" {
+		c.BOMRef = m.Path
+	}
+
+	return c
+}
+
+// resolveModule returns a module replacement if there is one.
+// Local path replacements are not modules, the replaced module is returned instead.
+func resolveModule(m *debug.Module) *debug.Module {
+	if m.Replace != nil && !localModule(m) {
+		return m.Replace
+	}
+
+	return m
+}
+
+// localModule reports whether a module is replaced by a local directory, which has no purl
+func localModule(m *debug.Module) bool {
+	if m.Replace == nil {
+		return false
+	}
+
+	p := filepath.ToSlash(m.Replace.Path)
+	return p == "." || p == ".." || strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") || filepath.IsAbs(m.Replace.Path)
+}
+
+// modulePURL returns a package URL of a module, without a version when the module was built from a source tree
+func modulePURL(m *debug.Module) string {
+	if m.Version == "" || m.Version == "(devel)" {
+		return fmt.Sprintf("pkg:golang/%v", m.Path)
+	}
+
+	return fmt.Sprintf("pkg:golang/%v@%v", m.Path, m.Version)
+}
+
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "error generating uuid")
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,337 @@
+package release_test
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"git-release/release"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSBOM(t *testing.T) {
+	log.SetOutput(io.Discard)
+
+	a := assert.New(t)
+	roBase := afero.NewReadOnlyFs(afero.NewOsFs())
+	fs := afero.NewCopyOnWriteFs(roBase, afero.NewMemMapFs())
+
+	// test binary is a Go binary with embedded build info
+	binary := os.Args[0]
+
+	type expected struct {
+		Result *release.Asset
+		Fields map[string]string
+		Error  string
+	}
+
+	type test struct {
+		Asset    release.Asset
+		Format   string
+		Files    []string
+		Expected expected
+	}
+
+	suite := map[string]test{
+		"CycloneDX": {
+			Asset: release.Asset{
+				Name: "app",
+				Path: binary,
+			},
+			Format: release.SBOMFormatCycloneDX,
+			Expected: expected{
+				Result: &release.Asset{
+					Name:      "app.sbom.json",
+					Path:      binary + ".sbom.json",
+					Generated: true,
+				},
+				Fields: map[string]string{
+					"bomFormat":   "CycloneDX",
+					"specVersion": "1.5",
+				},
+				Error: "",
+			},
+		},
+		"SPDX": {
+			Asset: release.Asset{
+				Name: "app",
+				Path: binary,
+			},
+			Format: release.SBOMFormatSPDX,
+			Expected: expected{
+				Result: &release.Asset{
+					Name:      "app.sbom.json",
+					Path:      binary + ".sbom.json",
+					Generated: true,
+				},
+				Fields: map[string]string{
+					"spdxVersion": "SPDX-2.3",
+					"name":        "app",
+				},
+				Error: "",
+			},
+		},
+		"Not a Go Binary": {
+			Asset: release.Asset{
+				Name: "file1",
+				Path: "file1",
+			},
+			Format: release.SBOMFormatCycloneDX,
+			Files:  []string{"file1"},
+			Expected: expected{
+				Result: nil,
+				Error:  "",
+			},
+		},
+		"Unsupported Format": {
+			Asset: release.Asset{
+				Name: "app",
+				Path: binary,
+			},
+			Format: "swid",
+			Expected: expected{
+				Result: nil,
+				Error:  "unsupported sbom format swid",
+			},
+		},
+		"File Does Not Exist": {
+			Asset: release.Asset{
+				Name: "file2",
+				Path: "file2",
+			},
+			Format: release.SBOMFormatCycloneDX,
+			Expected: expected{
+				Result: nil,
+				Error:  "error opening a file: open file2: file does not exist",
+			},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		for _, f := range test.Files {
+			if err := afero.WriteFile(fs, f, []byte("content"), 0644); err != nil {
+				t.Errorf("error preparing test case: error creating file %v: %v", f, err)
+				continue
+			}
+		}
+
+		// test
+		r, err := test.Asset.GenerateSBOM(fs, test.Format)
+		a.Equal(test.Expected.Result, r)
+		if test.Expected.Error != "" || err != nil {
+			a.EqualError(err, test.Expected.Error)
+		}
+
+		if r != nil {
+			b, err := afero.ReadFile(fs, r.Path)
+			a.Nil(err)
+
+			document := make(map[string]interface{})
+			a.Nil(json.Unmarshal(b, &document))
+
+			for k, v := range test.Expected.Fields {
+				a.Equal(v, document[k])
+			}
+		}
+
+		// cleanup
+		if r != nil {
+			if err := fs.Remove(r.Path); err != nil {
+				t.Errorf("error cleanup: error removing file %v: %v", r.Path, err)
+			}
+		}
+
+		for _, f := range test.Files {
+			if err := fs.Remove(f); err != nil {
+				t.Errorf("error cleanup: error removing file %v: %v", f, err)
+			}
+		}
+	}
+}
+
+func TestGenerateSBOMs(t *testing.T) {
+	log.SetOutput(io.Discard)
+
+	a := assert.New(t)
+	roBase := afero.NewReadOnlyFs(afero.NewOsFs())
+	fs := afero.NewCopyOnWriteFs(roBase, afero.NewMemMapFs())
+
+	binary := os.Args[0]
+	name := filepath.Base(binary)
+
+	if err := afero.WriteFile(fs, "file1", []byte("content"), 0644); err != nil {
+		t.Fatalf("error preparing test case: error creating file file1: %v", err)
+	}
+
+	r := &release.Release{
+		Assets: &[]release.Asset{
+			{
+				Name: name,
+				Path: binary,
+			},
+			{
+				Name: "file1",
+				Path: "file1",
+			},
+		},
+	}
+
+	a.Nil(r.GenerateSBOMs(fs, release.SBOMFormatSPDX))
+	a.Equal(&[]release.Asset{
+		{
+			Name: name,
+			Path: binary,
+		},
+		{
+			Name: "file1",
+			Path: "file1",
+		},
+		{
+			Name:      name + ".sbom.json",
+			Path:      binary + ".sbom.json",
+			Generated: true,
+		},
+	}, r.Assets)
+
+	// sbom matched by an asset pattern on a rerun should not be attached twice
+	a.Nil(r.GenerateSBOMs(fs, release.SBOMFormatSPDX))
+	a.Len(*r.Assets, 3)
+
+	// sbom supplied by a user should neither be overwritten nor attached twice
+	if err := afero.WriteFile(fs, "app.sbom.json", []byte("sbom"), 0644); err != nil {
+		t.Fatalf("error preparing test case: error creating file app.sbom.json: %v", err)
+	}
+
+	r = &release.Release{
+		Assets: &[]release.Asset{
+			{
+				Name: "app",
+				Path: binary,
+			},
+			{
+				Name: "app.sbom.json",
+				Path: "app.sbom.json",
+			},
+		},
+	}
+
+	a.Nil(r.GenerateSBOMs(fs, release.SBOMFormatSPDX))
+	a.Len(*r.Assets, 2)
+
+	b, err := afero.ReadFile(fs, "app.sbom.json")
+	a.Nil(err)
+	a.Equal("sbom", string(b))
+}
+
+func TestGenerateSBOMLocalModules(t *testing.T) {
+	log.SetOutput(io.Discard)
+
+	a := assert.New(t)
+	fs := afero.NewOsFs()
+
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not found")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"app/go.mod":     "module example.com/app\n\ngo 1.21\n\nrequire example.com/lib v0.0.0\n\nreplace example.com/lib => ../lib\n",
+		"app/main.go":    "package main\n\nimport \"example.com/lib\"\n\nfunc main() { lib.Run() }\n",
+		"lib/go.mod":     "module example.com/lib\n\ngo 1.21\n",
+		"lib/lib.go":     "package lib\n\nfunc Run() {}\n",
+		"script/main.go": "package main\n\nfunc main() {}\n",
+	}
+	for f, content := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(f)), 0755); err != nil {
+			t.Fatalf("error preparing test case: error creating directory for %v: %v", f, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, f), []byte(content), 0644); err != nil {
+			t.Fatalf("error preparing test case: error creating file %v: %v", f, err)
+		}
+	}
+
+	build := func(wd string, args ...string) {
+		cmd := exec.Command("go", append([]string{"build", "-o", "bin"}, args...)...)
+		cmd.Dir = filepath.Join(dir, wd)
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off", "CGO_ENABLED=0")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("error preparing test case: error building %v: %v: %s", wd, err, out)
+		}
+	}
+	build("app")
+	build("script", "main.go")
+
+	type component struct {
+		Name   string `json:"name"`
+		PURL   string `json:"purl"`
+		BOMRef string `json:"bom-ref"`
+	}
+
+	type document struct {
+		Metadata struct {
+			Component component `json:"component"`
+		} `json:"metadata"`
+		Components   []component `json:"components"`
+		Dependencies []struct {
+			Ref string `json:"ref"`
+		} `json:"dependencies"`
+		Packages []struct {
+			Name         string        `json:"name"`
+			ExternalRefs []interface{} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+
+	read := func(asset release.Asset, format string) *document {
+		s, err := asset.GenerateSBOM(fs, format)
+		if err != nil || s == nil {
+			t.Fatalf("error generating sbom of %v: %v", asset.Name, err)
+		}
+
+		b, err := afero.ReadFile(fs, s.Path)
+		if err != nil {
+			t.Fatalf("error reading sbom of %v: %v", asset.Name, err)
+		}
+
+		d := new(document)
+		if err := json.Unmarshal(b, d); err != nil {
+			t.Fatalf("error parsing sbom of %v: %v", asset.Name, err)
+		}
+
+		return d
+	}
+
+	// local replacement is not a resolvable package
+	app := release.Asset{Name: "app", Path: filepath.Join(dir, "app", "bin")}
+
+	d := read(app, release.SBOMFormatCycloneDX)
+	a.Equal("pkg:golang/example.com/app", d.Metadata.Component.PURL)
+	a.Len(d.Components, 1)
+	a.Equal("example.com/lib", d.Components[0].Name)
+	a.Equal("", d.Components[0].PURL)
+	a.Equal("example.com/lib", d.Components[0].BOMRef)
+
+	d = read(app, release.SBOMFormatSPDX)
+	a.Len(d.Packages, 2)
+	a.Equal("example.com/lib", d.Packages[1].Name)
+	a.Nil(d.Packages[1].ExternalRefs)
+
+	// binary built out of a module has no main module path
+	script := release.Asset{Name: "script", Path: filepath.Join(dir, "script", "bin")}
+
+	d = read(script, release.SBOMFormatCycloneDX)
+	a.Equal("command-line-arguments", d.Metadata.Component.Name)
+	a.Equal("command-line-arguments", d.Metadata.Component.BOMRef)
+	a.Equal("command-line-arguments", d.Dependencies[0].Ref)
+}
@@ -0,0 +1,201 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	changelog "github.com/anton-yurchenko/go-changelog"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	VersionSchemeSemVer string = "semver"
+	VersionSchemeCalVer string = "calver"
+	VersionSchemeRegex  string = "regex"
+	CalVerDefaultFormat string = "YYYY.0M.MICRO"
+)
+
+// VersionScheme defines how versions are matched in tags and ordered
+type VersionScheme interface {
+	// Expression returns a regular expression matching a version, it may contain a named 'version' group
+	Expression() string
+	// Compare returns -1, 0 or +1 depending on whether v1 < v2, v1 == v2, or v1 > v2
+	Compare(v1, v2 string) int
+}
+
+// NewVersionScheme returns a validated version scheme by its name
+func NewVersionScheme(name, format, expression string) (VersionScheme, error) {
+	switch name {
+	case VersionSchemeSemVer, "":
+		return SemVer{}, nil
+	case VersionSchemeCalVer:
+		if format == "" {
+			format = CalVerDefaultFormat
+		}
+
+		c := CalVer{Format: format}
+		if !regexp.MustCompile(`YYYY|YY|0Y|MM|0M|WW|0W|DD|0D|MAJOR|MINOR|MICRO`).MatchString(format) {
+			return nil, errors.New(fmt.Sprintf("calver format '%v' does not contain any of [YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MAJOR, MINOR, MICRO]", format))
+		}
+
+		return c, nil
+	case VersionSchemeRegex:
+		// expression is embedded into tag expressions that are anchored already
+		expression = strings.TrimPrefix(expression, "^")
+		if strings.HasSuffix(expression, "$") && !strings.HasSuffix(expression, `\$`) {
+			expression = strings.TrimSuffix(expression, "$")
+		}
+
+		r, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, errors.Wrap(err, "error compiling version regex")
+		}
+
+		if r.SubexpIndex("version") == -1 {
+			return nil, errors.New("version regex does not contain a named 'version' group")
+		}
+
+		return Regex{Pattern: expression}, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("version scheme '%v' not supported, possible values are [semver, calver, regex]", name))
+	}
+}
+
+// SemVer is a Semantic Versioning scheme
+type SemVer struct{}
+
+func (SemVer) Expression() string {
+	return changelog.SemVerRegex
+}
+
+func (SemVer) Compare(v1, v2 string) int {
+	return semver.Compare(fmt.Sprintf("v%v", v1), fmt.Sprintf("v%v", v2))
+}
+
+// CalVer is a Calendar Versioning scheme with a format like 'YYYY.0M.MICRO'
+type CalVer struct {
+	Format string
+}
+
+func (c CalVer) Expression() string {
+	tokens := regexp.MustCompile(`YYYY|YY|0Y|MM|0M|WW|0W|DD|0D|MAJOR|MINOR|MICRO|MODIFIER`)
+	expressions := map[string]string{
+		"YYYY":     `[1-9]\d{3}`,
+		"YY":       `(?:0|[1-9]\d{0,2})`,
+		"0Y":       `\d{2,3}`,
+		"MM":       `(?:1[0-2]|[1-9])`,
+		"0M":       `(?:0[1-9]|1[0-2])`,
+		"WW":       `(?:5[0-3]|[1-4]\d|[1-9])`,
+		"0W":       `(?:5[0-3]|[0-4]\d)`,
+		"DD":       `(?:3[01]|[12]\d|[1-9])`,
+		"0D":       `(?:0[1-9]|[12]\d|3[01])`,
+		"MAJOR":    `(?:0|[1-9]\d*)`,
+		"MINOR":    `(?:0|[1-9]\d*)`,
+		"MICRO":    `(?:0|[1-9]\d*)`,
+		"MODIFIER": `[0-9A-Za-z.-]+`,
+	}
+
+	var expression strings.Builder
+	var last int
+	for _, i := range tokens.FindAllStringIndex(c.Format, -1) {
+		expression.WriteString(regexp.QuoteMeta(c.Format[last:i[0]]))
+		expression.WriteString(expressions[c.Format[i[0]:i[1]]])
+		last = i[1]
+	}
+	expression.WriteString(regexp.QuoteMeta(c.Format[last:]))
+
+	if !strings.Contains(c.Format, "MODIFIER") {
+		// optional modifier, for example '2024.01.1-rc.1'
+		expression.WriteString(`(?:-[0-9A-Za-z.-]+)?`)
+	}
+
+	return expression.String()
+}
+
+func (CalVer) Compare(v1, v2 string) int {
+	return compareNatural(v1, v2)
+}
+
+// Regex is a custom version scheme matching a version by a regular expression with a named 'version' group
+type Regex struct {
+	Pattern string
+}
+
+func (r Regex) Expression() string {
+	return r.Pattern
+}
+
+func (Regex) Compare(v1, v2 string) int {
+	return compareNatural(v1, v2)
+}
+
+// compareNatural compares numeric parts of versions by value and the rest lexically.
+// A version followed by a '-' modifier is considered a pre-release of the same version without it.
+func compareNatural(v1, v2 string) int {
+	parts := regexp.MustCompile(`\d+|\D+`)
+	p1 := parts.FindAllString(v1, -1)
+	p2 := parts.FindAllString(v2, -1)
+
+	for i := 0; i < len(p1) && i < len(p2); i++ {
+		n1, err1 := strconv.ParseUint(p1[i], 10, 64)
+		n2, err2 := strconv.ParseUint(p2[i], 10, 64)
+
+		switch {
+		case err1 == nil && err2 == nil:
+			if n1 != n2 {
+				if n1 < n2 {
+					return -1
+				}
+				return 1
+			}
+		case p1[i] != p2[i]:
+			// pre-release modifier sorts before any other separator
+			if strings.HasPrefix(p1[i], "-") && !strings.HasPrefix(p2[i], "-") {
+				return -1
+			} else if strings.HasPrefix(p2[i], "-") && !strings.HasPrefix(p1[i], "-") {
+				return 1
+			}
+
+			return strings.Compare(p1[i], p2[i])
+		}
+	}
+
+	switch {
+	case len(p1) == len(p2):
+		return 0
+	case len(p1) > len(p2):
+		if strings.HasPrefix(p1[len(p2)], "-") {
+			return -1
+		}
+		return 1
+	default:
+		if strings.HasPrefix(p2[len(p1)], "-") {
+			return 1
+		}
+		return -1
+	}
+}
+
+// versionScheme returns a version scheme of release tags
+func (r *Release) versionScheme() VersionScheme {
+	if r.Scheme == nil {
+		return SemVer{}
+	}
+
+	return r.Scheme
+}
+
+// versionGroupIndex returns an index of the innermost named 'version' group of a regex
+func versionGroupIndex(regex *regexp.Regexp) int {
+	index := -1
+	for i, name := range regex.SubexpNames() {
+		if name == "version" {
+			index = i
+		}
+	}
+
+	return index
+}
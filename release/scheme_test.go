@@ -0,0 +1,231 @@
+package release_test
+
+import (
+	"os"
+	"testing"
+
+	"git-release/release"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVersionScheme(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Name          string
+		Format        string
+		Expression    string
+		Expected      release.VersionScheme
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"Default": {
+			Expected: release.SemVer{},
+		},
+		"SemVer": {
+			Name:     "semver",
+			Expected: release.SemVer{},
+		},
+		"CalVer": {
+			Name:     "calver",
+			Expected: release.CalVer{Format: "YYYY.0M.MICRO"},
+		},
+		"CalVer with Format": {
+			Name:     "calver",
+			Format:   "YY.MM.DD",
+			Expected: release.CalVer{Format: "YY.MM.DD"},
+		},
+		"CalVer with Malformed Format": {
+			Name:          "calver",
+			Format:        "year.month",
+			ExpectedError: "calver format 'year.month' does not contain any of",
+		},
+		"Regex": {
+			Name:       "regex",
+			Expression: `build-(?P<version>\d+)`,
+			Expected:   release.Regex{Pattern: `build-(?P<version>\d+)`},
+		},
+		"Anchored Regex": {
+			Name:       "regex",
+			Expression: `^build-(?P<version>\d+)$`,
+			Expected:   release.Regex{Pattern: `build-(?P<version>\d+)`},
+		},
+		"Regex Ending with Escaped Dollar": {
+			Name:       "regex",
+			Expression: `(?P<version>\d+)\$`,
+			Expected:   release.Regex{Pattern: `(?P<version>\d+)\$`},
+		},
+		"Regex without Version Group": {
+			Name:          "regex",
+			Expression:    `build-\d+`,
+			ExpectedError: "version regex does not contain a named 'version' group",
+		},
+		"Malformed Regex": {
+			Name:          "regex",
+			Expression:    `build-(\d+`,
+			ExpectedError: "error compiling version regex",
+		},
+		"Unsupported": {
+			Name:          "date",
+			ExpectedError: "version scheme 'date' not supported, possible values are [semver, calver, regex]",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		s, err := release.NewVersionScheme(test.Name, test.Format, test.Expression)
+		if test.ExpectedError != "" || err != nil {
+			a.ErrorContains(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, s)
+	}
+}
+
+func TestVersionSchemeReference(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Scheme        release.VersionScheme
+		GitHubRef     string
+		Prefix        string
+		Expected      *release.Reference
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"CalVer": {
+			Scheme:    release.CalVer{Format: "YYYY.0M.MICRO"},
+			GitHubRef: "refs/tags/v2024.03.2",
+			Expected: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v2024.03.2",
+				Version:    "2024.03.2",
+			},
+		},
+		"CalVer with Modifier and Prefix": {
+			Scheme:    release.CalVer{Format: "YY.0W"},
+			GitHubRef: "refs/tags/infra-24.09-rc.1",
+			Prefix:    "infra-",
+			Expected: &release.Reference{
+				CommitHash: "111",
+				Tag:        "infra-24.09-rc.1",
+				Version:    "24.09-rc.1",
+				Prefix:     "infra-",
+			},
+		},
+		"Malformed CalVer": {
+			Scheme:        release.CalVer{Format: "YYYY.0M.MICRO"},
+			GitHubRef:     "refs/tags/2024.3.2",
+			ExpectedError: "malformed env.var GITHUB_REF",
+		},
+		"Regex": {
+			Scheme:    release.Regex{Pattern: `build-(?P<version>\d+)`},
+			GitHubRef: "refs/tags/build-42",
+			Expected: &release.Reference{
+				CommitHash: "111",
+				Tag:        "build-42",
+				Version:    "42",
+			},
+		},
+		"Regex with Prefix": {
+			Scheme:    release.Regex{Pattern: `(?P<version>\d+)-stable`},
+			GitHubRef: "refs/tags/api/42-stable",
+			Prefix:    "[a-z]+/",
+			Expected: &release.Reference{
+				CommitHash: "111",
+				Tag:        "api/42-stable",
+				Version:    "42",
+				Prefix:     "api/",
+			},
+		},
+	}
+
+	defer func() {
+		os.Unsetenv("GITHUB_REF")
+		os.Unsetenv("GITHUB_SHA")
+	}()
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		os.Setenv("GITHUB_REF", test.GitHubRef)
+		os.Setenv("GITHUB_SHA", "111")
+
+		ref, err := release.GetReference(test.Prefix, test.Scheme, "", false)
+		if test.ExpectedError != "" || err != nil {
+			a.ErrorContains(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, ref)
+	}
+}
+
+func TestVersionSchemeCompare(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Scheme   release.VersionScheme
+		V1       string
+		V2       string
+		Expected int
+	}
+
+	suite := map[string]test{
+		"SemVer": {
+			Scheme:   release.SemVer{},
+			V1:       "1.10.0",
+			V2:       "1.9.0",
+			Expected: 1,
+		},
+		"SemVer Pre-Release": {
+			Scheme:   release.SemVer{},
+			V1:       "1.0.0-rc.1",
+			V2:       "1.0.0",
+			Expected: -1,
+		},
+		"CalVer": {
+			Scheme:   release.CalVer{Format: "YYYY.0M.MICRO"},
+			V1:       "2024.09.1",
+			V2:       "2024.10.0",
+			Expected: -1,
+		},
+		"CalVer Micro": {
+			Scheme:   release.CalVer{Format: "YYYY.0M.MICRO"},
+			V1:       "2024.10.10",
+			V2:       "2024.10.9",
+			Expected: 1,
+		},
+		"CalVer Modifier": {
+			Scheme:   release.CalVer{Format: "YYYY.0M.MICRO"},
+			V1:       "2024.10.1",
+			V2:       "2024.10.1-rc.1",
+			Expected: 1,
+		},
+		"Equal": {
+			Scheme:   release.Regex{Pattern: `(?P<version>\d+)`},
+			V1:       "42",
+			V2:       "42",
+			Expected: 0,
+		},
+		"Regex": {
+			Scheme:   release.Regex{Pattern: `(?P<version>\d+)`},
+			V1:       "9",
+			V2:       "42",
+			Expected: -1,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a.Equal(test.Expected, test.Scheme.Compare(test.V1, test.V2))
+	}
+}
@@ -0,0 +1,216 @@
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+// TrustedKeys is a set of public keys allowed to sign release tags
+type TrustedKeys struct {
+	PGP openpgp.EntityList
+	SSH []ssh.PublicKey
+}
+
+// ParseTrustedKeys parses armored PGP public key blocks and SSH public keys ('authorized_keys'/'allowed_signers' lines)
+func ParseTrustedKeys(data []byte) (*TrustedKeys, error) {
+	keys := new(TrustedKeys)
+
+	blocks := regexp.MustCompile(`(?s)-----BEGIN PGP PUBLIC KEY BLOCK-----.*?-----END PGP PUBLIC KEY BLOCK-----`)
+	for _, b := range blocks.FindAll(data, -1) {
+		l, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(b))
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing pgp public key")
+		}
+
+		keys.PGP = append(keys.PGP, l...)
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(blocks.ReplaceAll(data, nil)))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		k, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing ssh public key '%v'", line)
+		}
+
+		keys.SSH = append(keys.SSH, k)
+	}
+
+	if len(keys.PGP) == 0 && len(keys.SSH) == 0 {
+		return nil, errors.New("trusted keys do not contain any pgp or ssh public key")
+	}
+
+	return keys, nil
+}
+
+// VerifyTag ensures that an annotated release tag is signed by one of the trusted keys and points at the released commit
+func (r *Release) VerifyTag(cli GitClient, keys *TrustedKeys) error {
+	tag, err := r.GetTagObject(cli)
+	if err != nil {
+		return err
+	}
+
+	if tag == nil {
+		return errors.New(fmt.Sprintf("tag %v is not annotated", r.Reference.Tag))
+	}
+
+	if tag.GetObject().GetType() != "commit" || tag.GetObject().GetSHA() != r.Reference.CommitHash {
+		return errors.New(fmt.Sprintf("tag %v points at %v %v instead of GITHUB_SHA %v", r.Reference.Tag, tag.GetObject().GetType(), tag.GetObject().GetSHA(), r.Reference.CommitHash))
+	}
+
+	if tag.GetVerification().GetSignature() == "" || tag.GetVerification().GetPayload() == "" {
+		return errors.New(fmt.Sprintf("tag %v is not signed", r.Reference.Tag))
+	}
+
+	signer, err := keys.Verify([]byte(tag.GetVerification().GetPayload()), tag.GetVerification().GetSignature())
+	if err != nil {
+		return errors.Wrapf(err, "error verifying %v tag signature", r.Reference.Tag)
+	}
+
+	// signed payload (not the API response) has to describe the released tag, otherwise an old signed
+	// tag object of the same commit could be pushed under a new name
+	headers := tagPayloadHeaders(tag.GetVerification().GetPayload())
+	if headers["object"] != r.Reference.CommitHash || headers["type"] != "commit" {
+		return errors.New(fmt.Sprintf("signed tag %v points at %v %v instead of GITHUB_SHA %v", r.Reference.Tag, headers["type"], headers["object"], r.Reference.CommitHash))
+	}
+
+	if headers["tag"] != r.Reference.Tag {
+		return errors.New(fmt.Sprintf("signed tag name '%v' does not match %v", headers["tag"], r.Reference.Tag))
+	}
+
+	log.Infof("tag %v is signed by %v", r.Reference.Tag, signer)
+	return nil
+}
+
+// tagPayloadHeaders returns header lines ('object', 'type', 'tag', 'tagger') of a tag object payload
+func tagPayloadHeaders(payload string) map[string]string {
+	headers := make(map[string]string)
+	for _, line := range strings.Split(payload, "\n") {
+		if line == "" {
+			break
+		}
+
+		if k, v, ok := strings.Cut(line, " "); ok {
+			if _, exists := headers[k]; !exists {
+				headers[k] = v
+			}
+		}
+	}
+
+	return headers
+}
+
+// Verify checks a PGP or SSH signature of a payload and returns an identity of the signing key
+func (k *TrustedKeys) Verify(payload []byte, signature string) (string, error) {
+	switch {
+	case strings.HasPrefix(signature, "-----BEGIN PGP SIGNATURE-----"):
+		if len(k.PGP) == 0 {
+			return "", errors.New("no trusted pgp keys")
+		}
+
+		e, err := openpgp.CheckArmoredDetachedSignature(k.PGP, bytes.NewReader(payload), strings.NewReader(signature), nil)
+		if err != nil {
+			return "", errors.Wrap(err, "signature does not match any trusted pgp key")
+		}
+
+		if i := e.PrimaryIdentity(); i != nil {
+			return i.Name, nil
+		}
+
+		return fmt.Sprintf("%X", e.PrimaryKey.Fingerprint), nil
+	case strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----"):
+		if len(k.SSH) == 0 {
+			return "", errors.New("no trusted ssh keys")
+		}
+
+		return verifySSHSignature(k.SSH, payload, signature)
+	default:
+		return "", errors.New("unsupported signature format (expected pgp or ssh)")
+	}
+}
+
+// verifySSHSignature verifies an 'sshsig' signature made by 'git' namespace
+func verifySSHSignature(keys []ssh.PublicKey, payload []byte, signature string) (string, error) {
+	block, _ := pem.Decode([]byte(signature))
+	if block == nil || block.Type != "SSH SIGNATURE" || !bytes.HasPrefix(block.Bytes, []byte("SSHSIG")) {
+		return "", errors.New("malformed ssh signature")
+	}
+
+	sig := new(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	})
+	if err := ssh.Unmarshal(block.Bytes[6:], sig); err != nil {
+		return "", errors.Wrap(err, "error parsing ssh signature")
+	}
+
+	if sig.Namespace != "git" {
+		return "", errors.New(fmt.Sprintf("unexpected ssh signature namespace '%v'", sig.Namespace))
+	}
+
+	pub, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing ssh signature public key")
+	}
+
+	var trusted bool
+	for _, k := range keys {
+		if bytes.Equal(k.Marshal(), pub.Marshal()) {
+			trusted = true
+			break
+		}
+	}
+
+	if !trusted {
+		return "", errors.New(fmt.Sprintf("ssh key %v is not trusted", ssh.FingerprintSHA256(pub)))
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return "", errors.New(fmt.Sprintf("unsupported ssh signature hash algorithm '%v'", sig.HashAlgorithm))
+	}
+	h.Write(payload)
+
+	s := new(ssh.Signature)
+	if err := ssh.Unmarshal(sig.Signature, s); err != nil {
+		return "", errors.Wrap(err, "error parsing ssh signature blob")
+	}
+
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{sig.Namespace, sig.Reserved, sig.HashAlgorithm, h.Sum(nil)})...)
+
+	if err := pub.Verify(signed, s); err != nil {
+		return "", errors.Wrap(err, "signature does not match ssh key")
+	}
+
+	return ssh.FingerprintSHA256(pub), nil
+}
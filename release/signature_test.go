@@ -0,0 +1,268 @@
+package release_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"io"
+	"strings"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/google/go-github/github"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+const tagPayload string = `object 111
+type commit
+tag v1.0.0
+tagger Anton Yurchenko <anton@example.com> 1704067200 +0000
+
+Release v1.0.0
+`
+
+func pgpKey(t *testing.T) (*openpgp.Entity, string) {
+	e, err := openpgp.NewEntity("Anton Yurchenko", "", "anton@example.com", nil)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating pgp key: %v", err)
+	}
+
+	var b bytes.Buffer
+	w, err := armor.Encode(&b, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("error preparing test case: error encoding pgp key: %v", err)
+	}
+
+	if err := e.Serialize(w); err != nil {
+		t.Fatalf("error preparing test case: error serializing pgp key: %v", err)
+	}
+	w.Close()
+
+	return e, b.String()
+}
+
+func pgpSign(t *testing.T, e *openpgp.Entity, payload string) string {
+	var b bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&b, e, strings.NewReader(payload), nil); err != nil {
+		t.Fatalf("error preparing test case: error signing payload: %v", err)
+	}
+
+	return b.String()
+}
+
+func sshKey(t *testing.T) (ssh.Signer, string) {
+	_, k, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating ssh key: %v", err)
+	}
+
+	s, err := ssh.NewSignerFromKey(k)
+	if err != nil {
+		t.Fatalf("error preparing test case: error loading ssh key: %v", err)
+	}
+
+	return s, string(ssh.MarshalAuthorizedKey(s.PublicKey()))
+}
+
+func sshSign(t *testing.T, s ssh.Signer, namespace, payload string) string {
+	h := sha512.Sum512([]byte(payload))
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{namespace, "", "sha512", h[:]})...)
+
+	sig, err := s.Sign(rand.Reader, signed)
+	if err != nil {
+		t.Fatalf("error preparing test case: error signing payload: %v", err)
+	}
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, s.PublicKey().Marshal(), namespace, "", "sha512", ssh.Marshal(sig)})...)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob}))
+}
+
+func TestParseTrustedKeys(t *testing.T) {
+	a := assert.New(t)
+
+	_, pgp := pgpKey(t)
+	_, pub := sshKey(t)
+
+	keys, err := release.ParseTrustedKeys([]byte(strings.Join([]string{"# maintainers", pgp, "anton@example.com " + pub}, "\n")))
+	a.Equal(nil, err)
+	a.Equal(1, len(keys.PGP))
+	a.Equal(1, len(keys.SSH))
+
+	_, err = release.ParseTrustedKeys([]byte("# maintainers\n"))
+	a.EqualError(err, "trusted keys do not contain any pgp or ssh public key")
+
+	_, err = release.ParseTrustedKeys([]byte("ssh-ed25519 abc"))
+	a.ErrorContains(err, "error parsing ssh public key 'ssh-ed25519 abc'")
+}
+
+func TestVerifyTag(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	pgpEntity, pgpPublic := pgpKey(t)
+	sshSigner, sshPublic := sshKey(t)
+	untrustedEntity, _ := pgpKey(t)
+	untrustedSigner, _ := sshKey(t)
+
+	keys, err := release.ParseTrustedKeys([]byte(pgpPublic + "\n" + sshPublic))
+	if err != nil {
+		t.Fatalf("error preparing test case: error parsing trusted keys: %v", err)
+	}
+
+	type test struct {
+		ObjectType    string
+		Target        string
+		Payload       string
+		Signature     string
+		ExpectedError string
+	}
+
+	suite := map[string]test{
+		"PGP Signature": {
+			ObjectType: "tag",
+			Target:     "111",
+			Payload:    tagPayload,
+			Signature:  pgpSign(t, pgpEntity, tagPayload),
+		},
+		"SSH Signature": {
+			ObjectType: "tag",
+			Target:     "111",
+			Payload:    tagPayload,
+			Signature:  sshSign(t, sshSigner, "git", tagPayload),
+		},
+		"Untrusted PGP Key": {
+			ObjectType:    "tag",
+			Target:        "111",
+			Payload:       tagPayload,
+			Signature:     pgpSign(t, untrustedEntity, tagPayload),
+			ExpectedError: "error verifying v1.0.0 tag signature: signature does not match any trusted pgp key: openpgp: signature made by unknown entity",
+		},
+		"Untrusted SSH Key": {
+			ObjectType:    "tag",
+			Target:        "111",
+			Payload:       tagPayload,
+			Signature:     sshSign(t, untrustedSigner, "git", tagPayload),
+			ExpectedError: "error verifying v1.0.0 tag signature: ssh key " + ssh.FingerprintSHA256(untrustedSigner.PublicKey()) + " is not trusted",
+		},
+		"Tampered Payload": {
+			ObjectType:    "tag",
+			Target:        "111",
+			Payload:       strings.Replace(tagPayload, "v1.0.0", "v1.0.1", 1),
+			Signature:     sshSign(t, sshSigner, "git", tagPayload),
+			ExpectedError: "error verifying v1.0.0 tag signature: signature does not match ssh key: ssh: signature did not verify",
+		},
+		"Mismatched Tag Name": {
+			ObjectType:    "tag",
+			Target:        "111",
+			Payload:       strings.Replace(tagPayload, "tag v1.0.0", "tag v0.9.0", 1),
+			Signature:     sshSign(t, sshSigner, "git", strings.Replace(tagPayload, "tag v1.0.0", "tag v0.9.0", 1)),
+			ExpectedError: "signed tag name 'v0.9.0' does not match v1.0.0",
+		},
+		"Mismatched Object": {
+			ObjectType:    "tag",
+			Target:        "111",
+			Payload:       strings.Replace(tagPayload, "object 111", "object 222", 1),
+			Signature:     pgpSign(t, pgpEntity, strings.Replace(tagPayload, "object 111", "object 222", 1)),
+			ExpectedError: "signed tag v1.0.0 points at commit 222 instead of GITHUB_SHA 111",
+		},
+		"Mismatched Object Type": {
+			ObjectType:    "tag",
+			Target:        "111",
+			Payload:       strings.Replace(tagPayload, "type commit", "type tree", 1),
+			Signature:     pgpSign(t, pgpEntity, strings.Replace(tagPayload, "type commit", "type tree", 1)),
+			ExpectedError: "signed tag v1.0.0 points at tree 111 instead of GITHUB_SHA 111",
+		},
+		"Wrong SSH Namespace": {
+			ObjectType:    "tag",
+			Target:        "111",
+			Payload:       tagPayload,
+			Signature:     sshSign(t, sshSigner, "file", tagPayload),
+			ExpectedError: "error verifying v1.0.0 tag signature: unexpected ssh signature namespace 'file'",
+		},
+		"Unsigned Tag": {
+			ObjectType:    "tag",
+			Target:        "111",
+			ExpectedError: "tag v1.0.0 is not signed",
+		},
+		"Lightweight Tag": {
+			ObjectType:    "commit",
+			ExpectedError: "tag v1.0.0 is not annotated",
+		},
+		"Different Commit": {
+			ObjectType:    "tag",
+			Target:        "222",
+			Payload:       tagPayload,
+			Signature:     pgpSign(t, pgpEntity, tagPayload),
+			ExpectedError: "tag v1.0.0 points at commit 222 instead of GITHUB_SHA 111",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.0.0",
+				Version:    "1.0.0",
+			},
+		}
+
+		// test
+		m := new(mocks.GitClient)
+
+		m.On("GetRef",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name,
+			"refs/tags/v1.0.0").Return(&github.Reference{Object: &github.GitObject{Type: stringP(test.ObjectType), SHA: stringP("aaa")}}, nil, nil).Once()
+
+		if test.ObjectType == "tag" {
+			m.On("GetTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"aaa").Return(&github.Tag{
+				Object: &github.GitObject{Type: stringP("commit"), SHA: stringP(test.Target)},
+				Verification: &github.SignatureVerification{
+					Payload:   stringP(test.Payload),
+					Signature: stringP(test.Signature),
+				},
+			}, nil, nil).Once()
+		}
+
+		err := rel.VerifyTag(m, keys)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+	}
+}
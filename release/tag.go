@@ -0,0 +1,92 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	BodySourceChangelog string = "changelog"
+	BodySourceTag       string = "tag"
+	BodySourceBoth      string = "both"
+)
+
+// TagMessage returns a message of an annotated release tag, lightweight tags have no message
+func (r *Release) TagMessage(cli GitClient) (string, error) {
+	tag, err := r.GetTagObject(cli)
+	if err != nil || tag == nil {
+		return "", err
+	}
+
+	message := tag.GetMessage()
+	// signed tags carry a signature at the end of the message
+	for _, s := range []string{"-----BEGIN PGP SIGNATURE-----", "-----BEGIN SSH SIGNATURE-----", "-----BEGIN SIGNED MESSAGE-----"} {
+		if i := strings.Index(message, s); i != -1 {
+			message = message[:i]
+		}
+	}
+
+	return strings.TrimSpace(message), nil
+}
+
+// GetTagObject returns an annotated release tag object, or nil for a lightweight tag
+func (r *Release) GetTagObject(cli GitClient) (*github.Tag, error) {
+	ref, _, err := cli.GetRef(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		fmt.Sprintf("refs/tags/%v", r.Reference.Tag),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving %v tag", r.Reference.Tag)
+	}
+
+	if ref.GetObject().GetType() != "tag" {
+		return nil, nil
+	}
+
+	tag, _, err := cli.GetTag(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		ref.GetObject().GetSHA(),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving %v tag object", r.Reference.Tag)
+	}
+
+	return tag, nil
+}
+
+// CombineBody merges changelog with a tag message according to 'source'
+func CombineBody(source, changelog, message string) string {
+	message = strings.TrimRight(message, "\n")
+
+	switch source {
+	case BodySourceTag:
+		if message == "" {
+			return ""
+		}
+
+		return fmt.Sprintf("%v\n", message)
+	case BodySourceBoth:
+		changelog = strings.TrimRight(changelog, "\n")
+
+		switch {
+		case message == "" && changelog == "":
+			return ""
+		case message == "":
+			return fmt.Sprintf("%v\n", changelog)
+		case changelog == "":
+			return fmt.Sprintf("%v\n", message)
+		default:
+			return fmt.Sprintf("%v\n\n%v\n", message, changelog)
+		}
+	default:
+		return changelog
+	}
+}
@@ -0,0 +1,105 @@
+package release_test
+
+import (
+	"context"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagMessage(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		ObjectType      string
+		Message         string
+		GetRefMockError error
+		GetTagMockError error
+		Expected        string
+		ExpectedError   string
+	}
+
+	suite := map[string]test{
+		"Annotated Tag": {
+			ObjectType: "tag",
+			Message:    "Highlights\n\n- Feature A\n",
+			Expected:   "Highlights\n\n- Feature A",
+		},
+		"Signed Tag": {
+			ObjectType: "tag",
+			Message:    "- Feature A\n-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n-----END PGP SIGNATURE-----\n",
+			Expected:   "- Feature A",
+		},
+		"Lightweight Tag": {
+			ObjectType: "commit",
+			Expected:   "",
+		},
+		"Missing Tag": {
+			GetRefMockError: errors.New("reason"),
+			ExpectedError:   "error retrieving v1.0.0 tag: reason",
+		},
+		"Tag Object Error": {
+			ObjectType:      "tag",
+			GetTagMockError: errors.New("reason"),
+			ExpectedError:   "error retrieving v1.0.0 tag object: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				Tag:     "v1.0.0",
+				Version: "1.0.0",
+			},
+		}
+
+		// test
+		m := new(mocks.GitClient)
+
+		m.On("GetRef",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name,
+			"refs/tags/v1.0.0").Return(&github.Reference{Object: &github.GitObject{Type: stringP(test.ObjectType), SHA: stringP("aaa")}}, nil, test.GetRefMockError).Once()
+
+		if test.GetRefMockError == nil && test.ObjectType == "tag" {
+			m.On("GetTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"aaa").Return(&github.Tag{Message: stringP(test.Message)}, nil, test.GetTagMockError).Once()
+		}
+
+		msg, err := rel.TagMessage(m)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, msg)
+		m.AssertExpectations(t)
+	}
+}
+
+func TestCombineBody(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("- Feature A\n", release.CombineBody(release.BodySourceChangelog, "- Feature A\n", "Highlights"))
+	a.Equal("Highlights\n", release.CombineBody(release.BodySourceTag, "- Feature A\n", "Highlights"))
+	a.Equal("", release.CombineBody(release.BodySourceTag, "- Feature A\n", ""))
+	a.Equal("Highlights\n\n- Feature A\n", release.CombineBody(release.BodySourceBoth, "- Feature A\n", "Highlights"))
+	a.Equal("- Feature A\n", release.CombineBody(release.BodySourceBoth, "- Feature A\n", ""))
+	a.Equal("Highlights\n", release.CombineBody(release.BodySourceBoth, "", "Highlights\n\n"))
+	a.Equal("", release.CombineBody(release.BodySourceBoth, "", ""))
+}
@@ -0,0 +1,42 @@
+package release
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// NewHTTPClient returns an HTTP client trusting additional 'ca' certificates (on top of the system ones)
+// and presenting a client certificate for mTLS when 'cert' is set.
+// Proxy is configured by HTTPS_PROXY/NO_PROXY environmental variables.
+func NewHTTPClient(ca, cert, key []byte) (*http.Client, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	t.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(ca) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("error parsing CA bundle: PEM certificates not found")
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+
+	if len(cert) != 0 || len(key) != 0 {
+		c, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, errors.Wrap(err, "error loading client certificate")
+		}
+		t.TLSClientConfig.Certificates = []tls.Certificate{c}
+	}
+
+	return &http.Client{Transport: t}, nil
+}
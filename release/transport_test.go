@@ -0,0 +1,122 @@
+package release_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git-release/release"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	a := assert.New(t)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error preparing test case: error generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "git-release"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error preparing test case: error creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("error preparing test case: error marshaling key: %v", err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	certKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	type test struct {
+		CA                  []byte
+		Cert                []byte
+		Key                 []byte
+		ExpectedStatus      int
+		ExpectedError       string
+		ExpectedClientError bool
+	}
+
+	suite := map[string]test{
+		"Custom CA": {
+			CA:             ca,
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		"Custom CA and Client Certificate": {
+			CA:             ca,
+			Cert:           cert,
+			Key:            certKey,
+			ExpectedStatus: http.StatusOK,
+		},
+		"Untrusted Server": {
+			ExpectedClientError: true,
+		},
+		"Malformed CA": {
+			CA:            []byte("ca"),
+			ExpectedError: "error parsing CA bundle: PEM certificates not found",
+		},
+		"Missing Client Key": {
+			CA:            ca,
+			Cert:          cert,
+			ExpectedError: "error loading client certificate: tls: failed to find any PEM data in key input",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// test
+		cli, err := release.NewHTTPClient(test.CA, test.Cert, test.Key)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+			continue
+		}
+
+		res, err := cli.Get(srv.URL)
+		if test.ExpectedClientError {
+			a.Error(err)
+			continue
+		}
+
+		if a.NoError(err) {
+			a.Equal(test.ExpectedStatus, res.StatusCode)
+			res.Body.Close()
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const (
+	SkipUnchangedCommit  string = "commit"
+	SkipUnchangedContent string = "content"
+)
+
+// Unchanged reports whether a previously published Unreleased release exists and its tag points at the current commit.
+// When 'content' is set, release title, body and assets digests are compared as well. Generated assets (SBOMs, provenance)
+// embed a timestamp and a unique ID, so only their presence is compared.
+func (r *Release) Unchanged(fs afero.Fs, repoCli RepositoriesClient, gitCli GitClient, httpCli *http.Client, content bool) (bool, error) {
+	ref, _, err := gitCli.GetRef(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		fmt.Sprintf("refs/tags/%v", r.Reference.Tag),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return false, nil
+		}
+
+		return false, errors.Wrap(err, "error fetching precedent tag")
+	}
+
+	if ref.GetObject().GetSHA() != r.Reference.CommitHash {
+		log.Infof("%v tag points at %v, updating release", r.Reference.Tag, ref.GetObject().GetSHA())
+		return false, nil
+	}
+
+	// a tag may outlive its release when a previous run failed to publish it
+	previous, _, err := repoCli.GetReleaseByTag(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		r.Reference.Tag,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			log.Infof("%v release not found, publishing release", r.Reference.Tag)
+			return false, nil
+		}
+
+		return false, errors.Wrapf(err, "error retrieving a precedent release with a tag %v", r.Reference.Tag)
+	}
+
+	if !content {
+		return true, nil
+	}
+
+	if previous.GetName() != r.Name || previous.GetBody() != r.Changelog {
+		log.Info("release title or body changed, updating release")
+		return false, nil
+	}
+
+	var assets []Asset
+	if r.Assets != nil {
+		assets = *r.Assets
+	}
+
+	if len(previous.Assets) != len(assets) {
+		log.Info("release assets changed, updating release")
+		return false, nil
+	}
+
+	for _, a := range assets {
+		remote := a.Find(previous.Assets)
+		if remote == nil {
+			log.WithField("asset", a.Name).Info("asset not uploaded, updating release")
+			return false, nil
+		}
+
+		if a.Generated {
+			continue
+		}
+
+		local, err := a.Digest(fs)
+		if err != nil {
+			return false, err
+		}
+
+		digest, err := r.remoteDigest(repoCli, httpCli, remote.GetID())
+		if err != nil {
+			return false, errors.Wrapf(err, "error downloading release asset %v", a.Name)
+		}
+
+		if local != digest {
+			log.WithField("asset", a.Name).Info("asset changed, updating release")
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
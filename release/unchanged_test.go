@@ -0,0 +1,205 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnchanged(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "build/linux.zip", []byte("content"), 0644); err != nil {
+		t.Fatalf("error preparing test case: error creating file: %v", err)
+	}
+
+	type test struct {
+		Content          bool
+		TagSHA           string
+		GetRefMockError  error
+		Body             string
+		Assets           []github.ReleaseAsset
+		SBOM             bool
+		RemoteContent    string
+		GetReleaseError  error
+		ExpectedDownload bool
+		Expected         bool
+		ExpectedError    string
+	}
+
+	uploaded := []github.ReleaseAsset{{ID: int64P(10), Name: stringP("linux.zip")}}
+	uploadedWithSBOM := []github.ReleaseAsset{{ID: int64P(10), Name: stringP("linux.zip")}, {ID: int64P(11), Name: stringP("linux.zip.sbom.json")}}
+	notFound := errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release/releases/tags/latest: 404 Not Found []")
+
+	suite := map[string]test{
+		"Same Commit": {
+			TagSHA:   "111",
+			Expected: true,
+		},
+		"Same Commit without Release": {
+			TagSHA:          "111",
+			GetReleaseError: notFound,
+			Expected:        false,
+		},
+		"Release Error": {
+			TagSHA:          "111",
+			GetReleaseError: errors.New("reason"),
+			ExpectedError:   "error retrieving a precedent release with a tag latest: reason",
+		},
+		"Different Commit": {
+			TagSHA:   "000",
+			Content:  true,
+			Expected: false,
+		},
+		"Missing Tag": {
+			GetRefMockError: errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release/git/refs/tags/latest: 404 Not Found []"),
+			Expected:        false,
+		},
+		"Tag Error": {
+			GetRefMockError: errors.New("reason"),
+			ExpectedError:   "error fetching precedent tag: reason",
+		},
+		"Same Content": {
+			Content:          true,
+			TagSHA:           "111",
+			Body:             "changelog",
+			Assets:           uploaded,
+			RemoteContent:    "content",
+			ExpectedDownload: true,
+			Expected:         true,
+		},
+		"Different Body": {
+			Content:  true,
+			TagSHA:   "111",
+			Body:     "outdated changelog",
+			Assets:   uploaded,
+			Expected: false,
+		},
+		"Different Asset": {
+			Content:          true,
+			TagSHA:           "111",
+			Body:             "changelog",
+			Assets:           uploaded,
+			RemoteContent:    "outdated content",
+			ExpectedDownload: true,
+			Expected:         false,
+		},
+		"Missing Asset": {
+			Content:  true,
+			TagSHA:   "111",
+			Body:     "changelog",
+			Expected: false,
+		},
+		"Missing Release": {
+			Content:         true,
+			TagSHA:          "111",
+			GetReleaseError: notFound,
+			Expected:        false,
+		},
+		"Same Content with Generated Asset": {
+			Content:          true,
+			TagSHA:           "111",
+			Body:             "changelog",
+			Assets:           uploadedWithSBOM,
+			SBOM:             true,
+			RemoteContent:    "content",
+			ExpectedDownload: true,
+			Expected:         true,
+		},
+		"Missing Generated Asset": {
+			Content:          true,
+			TagSHA:           "111",
+			Body:             "changelog",
+			Assets:           append(uploaded, github.ReleaseAsset{ID: int64P(12), Name: stringP("darwin.zip")}),
+			SBOM:             true,
+			RemoteContent:    "content",
+			ExpectedDownload: true,
+			Expected:         false,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Name: "Latest",
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "latest",
+				Version:    "Unreleased",
+			},
+			Changelog: "changelog",
+			Assets: &[]release.Asset{
+				{
+					Name: "linux.zip",
+					Path: "build/linux.zip",
+				},
+			},
+		}
+
+		if test.SBOM {
+			*rel.Assets = append(*rel.Assets, release.Asset{
+				Name:      "linux.zip.sbom.json",
+				Path:      "build/linux.zip.sbom.json",
+				Generated: true,
+			})
+		}
+
+		// test
+		repoMock := new(mocks.RepositoriesClient)
+		gitMock := new(mocks.GitClient)
+
+		gitMock.On("GetRef",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name,
+			"refs/tags/latest").Return(&github.Reference{Object: &github.GitObject{SHA: stringP(test.TagSHA)}}, nil, test.GetRefMockError).Once()
+
+		if test.GetRefMockError == nil && test.TagSHA == "111" {
+			repoMock.On("GetReleaseByTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"latest").Return(&github.RepositoryRelease{
+				Name:   stringP("Latest"),
+				Body:   stringP(test.Body),
+				Assets: test.Assets,
+			}, nil, test.GetReleaseError).Once()
+		}
+
+		if test.ExpectedDownload {
+			repoMock.On("DownloadReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(10)).Return(io.NopCloser(strings.NewReader(test.RemoteContent)), "", nil).Once()
+		}
+
+		unchanged, err := rel.Unchanged(fs, repoMock, gitMock, http.DefaultClient, test.Content)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		a.Equal(test.Expected, unchanged)
+		repoMock.AssertExpectations(t)
+		gitMock.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,303 @@
+package release
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	changelog "github.com/anton-yurchenko/go-changelog"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	SeverityError   string = "error"
+	SeverityWarning string = "warning"
+)
+
+// Problem is a single changelog validation finding
+type Problem struct {
+	Severity string
+	Line     int
+	Message  string
+}
+
+// Annotation formats a problem as a GitHub Actions workflow command
+func (p Problem) Annotation(file string) string {
+	message := escapeData(p.Message)
+	file = escapeProperty(file)
+
+	if p.Line == 0 {
+		return fmt.Sprintf("::%v file=%v::%v", p.Severity, file, message)
+	}
+
+	return fmt.Sprintf("::%v file=%v,line=%v::%v", p.Severity, file, p.Line, message)
+}
+
+// escapeData escapes a workflow command message
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command property value
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+type changelogVersion struct {
+	Version string
+	Line    int
+	Link    bool
+}
+
+type changelogSection struct {
+	Title   string
+	Line    int
+	Entries int
+}
+
+// ValidateChangelog parses a changelog file and reports its problems
+func ValidateChangelog(fs afero.Fs, file string) ([]Problem, error) {
+	problems := make([]Problem, 0)
+
+	if p := parseChangelog(fs, file); p != nil {
+		problems = append(problems, *p)
+	}
+
+	f, err := fs.Open(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening changelog file")
+	}
+	defer f.Close()
+
+	unreleasedTitle := regexp.MustCompile(changelog.UnreleasedTitleRegex)
+	unreleasedTitleWithLink := regexp.MustCompile(changelog.UnreleasedTitleWithLinkRegex)
+	versionTitle := regexp.MustCompile(changelog.VersionTitleRegex)
+	versionTitleWithLink := regexp.MustCompile(changelog.VersionTitleWithLinkRegex)
+	anyVersionTitle := regexp.MustCompile(`^## \[(?P<version>[^\]]*)\](?:\([^)]*\))?(?: - (?P<date>\S+))?.*$`)
+	link := regexp.MustCompile(`^\[(?P<title>[^\]]+)\]: \S+$`)
+	entry := regexp.MustCompile(changelog.EntryRegex)
+	version := regexp.MustCompile(fmt.Sprintf("^%v$", changelog.SemVerRegex))
+
+	scopes := map[string]bool{
+		"Added":      true,
+		"Changed":    true,
+		"Deprecated": true,
+		"Removed":    true,
+		"Fixed":      true,
+		"Security":   true,
+	}
+
+	versions := make([]changelogVersion, 0)
+	links := make(map[string]bool)
+
+	var unreleased *changelogSection
+	var inUnreleased bool
+	var section *changelogSection
+	closeSection := func() {
+		if section != nil && section.Entries == 0 {
+			problems = append(problems, Problem{
+				Severity: SeverityError,
+				Line:     section.Line,
+				Message:  fmt.Sprintf("section '%v' is empty", section.Title),
+			})
+		}
+		section = nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	var n int
+	for scanner.Scan() {
+		n++
+		line := strings.TrimRight(scanner.Text(), " \t")
+
+		switch {
+		case unreleasedTitle.MatchString(line) || unreleasedTitleWithLink.MatchString(line):
+			closeSection()
+			unreleased = &changelogSection{Title: "Unreleased", Line: n}
+			inUnreleased = true
+		case versionTitle.MatchString(line) || versionTitleWithLink.MatchString(line):
+			closeSection()
+			inUnreleased = false
+
+			v := anyVersionTitle.ReplaceAllString(line, "${1}")
+			date := anyVersionTitle.ReplaceAllString(line, "${2}")
+			if _, err := time.Parse(changelog.DateFormat, date); err != nil {
+				problems = append(problems, Problem{
+					Severity: SeverityError,
+					Line:     n,
+					Message:  fmt.Sprintf("version %v has an invalid date '%v' (expected format YYYY-MM-DD)", v, date),
+				})
+			}
+
+			versions = append(versions, changelogVersion{
+				Version: v,
+				Line:    n,
+				Link:    versionTitleWithLink.MatchString(line),
+			})
+		case strings.HasPrefix(line, "## "):
+			closeSection()
+			inUnreleased = false
+
+			if !anyVersionTitle.MatchString(line) {
+				problems = append(problems, Problem{
+					Severity: SeverityError,
+					Line:     n,
+					Message:  fmt.Sprintf("malformed version heading '%v' (expected '## [x.y.z] - YYYY-MM-DD')", line),
+				})
+				continue
+			}
+
+			v := anyVersionTitle.ReplaceAllString(line, "${1}")
+			date := anyVersionTitle.ReplaceAllString(line, "${2}")
+			if !version.MatchString(v) {
+				problems = append(problems, Problem{
+					Severity: SeverityError,
+					Line:     n,
+					Message:  fmt.Sprintf("version '%v' is not a valid semantic version", v),
+				})
+				continue
+			}
+
+			var msg string
+			if date == "" {
+				msg = fmt.Sprintf("version %v is missing a date", v)
+			} else if _, err := time.Parse(changelog.DateFormat, date); err != nil {
+				msg = fmt.Sprintf("version %v has an invalid date '%v' (expected format YYYY-MM-DD)", v, date)
+			} else {
+				msg = fmt.Sprintf("malformed version heading '%v' (expected '## [x.y.z] - YYYY-MM-DD')", line)
+			}
+
+			problems = append(problems, Problem{
+				Severity: SeverityError,
+				Line:     n,
+				Message:  msg,
+			})
+
+			versions = append(versions, changelogVersion{
+				Version: v,
+				Line:    n,
+				Link:    strings.HasPrefix(line, fmt.Sprintf("## [%v](", v)),
+			})
+		case strings.HasPrefix(line, "### "):
+			closeSection()
+
+			title := strings.TrimSpace(strings.TrimPrefix(line, "### "))
+			if !scopes[title] {
+				problems = append(problems, Problem{
+					Severity: SeverityError,
+					Line:     n,
+					Message:  fmt.Sprintf("unknown section heading '%v' (expected one of Added, Changed, Deprecated, Removed, Fixed, Security)", title),
+				})
+			}
+
+			section = &changelogSection{Title: title, Line: n}
+		case link.MatchString(line):
+			closeSection()
+			links[link.ReplaceAllString(line, "${1}")] = true
+		case entry.MatchString(line):
+			if section != nil {
+				section.Entries++
+			}
+			if inUnreleased {
+				unreleased.Entries++
+			}
+		case strings.TrimSpace(line) != "" && inUnreleased:
+			// a notice
+			unreleased.Entries++
+		}
+	}
+	closeSection()
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading changelog file")
+	}
+
+	if unreleased != nil && unreleased.Entries == 0 {
+		problems = append(problems, Problem{
+			Severity: SeverityWarning,
+			Line:     unreleased.Line,
+			Message:  "Unreleased section has no changes",
+		})
+	}
+
+	problems = append(problems, validateVersions(versions, links)...)
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+
+	return problems, nil
+}
+
+// parseChangelog ensures a changelog file may be parsed without errors
+func parseChangelog(fs afero.Fs, file string) (problem *Problem) {
+	defer func() {
+		if r := recover(); r != nil {
+			problem = &Problem{
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("changelog parser crashed: %v", r),
+			}
+		}
+	}()
+
+	p, err := changelog.NewParserWithFilesystem(fs, file)
+	if err != nil {
+		return &Problem{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("error loading changelog file: %v", err),
+		}
+	}
+
+	if _, err := p.Parse(); err != nil {
+		return &Problem{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("error parsing changelog file: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func validateVersions(versions []changelogVersion, links map[string]bool) []Problem {
+	problems := make([]Problem, 0)
+	seen := make(map[string]int)
+
+	for i, v := range versions {
+		if line, ok := seen[v.Version]; ok {
+			problems = append(problems, Problem{
+				Severity: SeverityError,
+				Line:     v.Line,
+				Message:  fmt.Sprintf("version %v is duplicated (first defined at line %v)", v.Version, line),
+			})
+			continue
+		}
+		seen[v.Version] = v.Line
+
+		if !v.Link && !links[v.Version] {
+			problems = append(problems, Problem{
+				Severity: SeverityError,
+				Line:     v.Line,
+				Message:  fmt.Sprintf("version %v is missing a compare link", v.Version),
+			})
+		}
+
+		if i > 0 {
+			previous := fmt.Sprintf("v%v", versions[i-1].Version)
+			current := fmt.Sprintf("v%v", v.Version)
+
+			if semver.IsValid(previous) && semver.IsValid(current) && semver.Compare(previous, current) < 0 {
+				problems = append(problems, Problem{
+					Severity: SeverityError,
+					Line:     v.Line,
+					Message:  fmt.Sprintf("version %v is not in descending order (listed after %v)", v.Version, versions[i-1].Version),
+				})
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,150 @@
+package release_test
+
+import (
+	"testing"
+
+	"git-release/release"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChangelog(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Content  string
+		Expected []release.Problem
+	}
+
+	suite := map[string]test{
+		"Valid": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Feature C
+
+## [1.1.0] - 2024-01-10
+
+### Added
+
+- Feature B
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+[Unreleased]: https://github.com/anton-yurchenko/git-release/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/anton-yurchenko/git-release/compare/v1.0.0...v1.1.0
+[1.0.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v1.0.0
+`,
+			Expected: []release.Problem{},
+		},
+		"Invalid": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+## [1.1.0] - 2024-02-30
+
+### Fixes
+
+- Bug A
+
+### Fixed
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- Feature A
+
+## [0.9.0]
+
+### Added
+
+- Feature Z
+
+[Unreleased]: https://github.com/anton-yurchenko/git-release/compare/v1.1.0...HEAD
+[1.0.0]: https://github.com/anton-yurchenko/git-release/compare/v0.9.0...v1.0.0
+[0.9.0]: https://github.com/anton-yurchenko/git-release/releases/tag/v0.9.0
+`,
+			Expected: []release.Problem{
+				{
+					Severity: release.SeverityWarning,
+					Line:     3,
+					Message:  "Unreleased section has no changes",
+				},
+				{
+					Severity: release.SeverityError,
+					Line:     11,
+					Message:  "version 1.1.0 has an invalid date '2024-02-30' (expected format YYYY-MM-DD)",
+				},
+				{
+					Severity: release.SeverityError,
+					Line:     11,
+					Message:  "version 1.1.0 is missing a compare link",
+				},
+				{
+					Severity: release.SeverityError,
+					Line:     11,
+					Message:  "version 1.1.0 is not in descending order (listed after 1.0.0)",
+				},
+				{
+					Severity: release.SeverityError,
+					Line:     13,
+					Message:  "unknown section heading 'Fixes' (expected one of Added, Changed, Deprecated, Removed, Fixed, Security)",
+				},
+				{
+					Severity: release.SeverityError,
+					Line:     17,
+					Message:  "section 'Fixed' is empty",
+				},
+				{
+					Severity: release.SeverityError,
+					Line:     19,
+					Message:  "version 1.0.0 is duplicated (first defined at line 5)",
+				},
+				{
+					Severity: release.SeverityError,
+					Line:     25,
+					Message:  "version 0.9.0 is missing a date",
+				},
+			},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		fs := afero.NewMemMapFs()
+		if err := afero.WriteFile(fs, "CHANGELOG.md", []byte(test.Content), 0644); err != nil {
+			t.Fatalf("error preparing test case: error creating file CHANGELOG.md: %v", err)
+		}
+
+		problems, err := release.ValidateChangelog(fs, "CHANGELOG.md")
+		a.Equal(nil, err)
+		a.Equal(test.Expected, problems)
+	}
+}
+
+func TestProblemAnnotation(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("::error file=CHANGELOG.md,line=3::message", release.Problem{Severity: release.SeverityError, Line: 3, Message: "message"}.Annotation("CHANGELOG.md"))
+	a.Equal("::warning file=CHANGELOG.md::message", release.Problem{Severity: release.SeverityWarning, Message: "message"}.Annotation("CHANGELOG.md"))
+	a.Equal("::error file=docs/a%3Ab%2Cc.md,line=1::100%25 done%0Anext%0D", release.Problem{Severity: release.SeverityError, Line: 1, Message: "100% done\nnext\r"}.Annotation("docs/a:b,c.md"))
+}
@@ -0,0 +1,229 @@
+package release
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// VerifyAssets downloads uploaded release assets and compares their SHA-256 digests with local files.
+// Corrupted or missing release assets are uploaded again when 'reupload' is set.
+// 'httpCli' is used to follow download redirects to a storage outside of the API.
+func (r *Release) VerifyAssets(fs afero.Fs, cli RepositoriesClient, httpCli *http.Client, reupload bool) error {
+	if r.Assets == nil || len(*r.Assets) == 0 {
+		return nil
+	}
+
+	failed, err := r.verifyAssets(fs, cli, httpCli, *r.Assets)
+	if err != nil {
+		return err
+	}
+
+	if len(failed) != 0 && reupload {
+		uploaded, err := r.ListAssets(cli)
+		if err != nil {
+			return err
+		}
+
+		assets := make([]Asset, 0)
+		for _, f := range failed {
+			a := f.Asset
+
+			if remote := a.Find(uploaded); remote != nil {
+				_, err := cli.DeleteReleaseAsset(
+					context.Background(),
+					r.Slug.Owner,
+					r.Slug.Name,
+					remote.GetID(),
+				)
+				if err != nil {
+					return errors.Wrapf(err, "error deleting corrupted release asset %v", a.Name)
+				}
+			}
+
+			log.WithField("asset", a.Name).Warn("uploading asset again")
+			assets = append(assets, a)
+		}
+
+		if err := r.uploadAssets(cli, r.ID, assets); err != nil {
+			return err
+		}
+
+		failed, err = r.verifyAssets(fs, cli, httpCli, assets)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(failed) != 0 {
+		msg := make([]string, 0)
+		for _, f := range failed {
+			msg = append(msg, fmt.Sprintf("%v (%v)", f.Asset.Name, f.Reason))
+		}
+
+		return errors.New(fmt.Sprintf("assets verification failed: %v", strings.Join(msg, ", ")))
+	}
+
+	log.Info("assets verified successfully 🎉")
+
+	return nil
+}
+
+type verificationFailure struct {
+	Asset  Asset
+	Reason string
+}
+
+func (r *Release) verifyAssets(fs afero.Fs, cli RepositoriesClient, httpCli *http.Client, assets []Asset) ([]verificationFailure, error) {
+	uploaded, err := r.ListAssets(cli)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make([]verificationFailure, 0)
+	for _, a := range assets {
+		remote := a.Find(uploaded)
+		if remote == nil {
+			log.WithField("asset", a.Name).Error("release asset not found")
+			failed = append(failed, verificationFailure{Asset: a, Reason: "missing"})
+			continue
+		}
+
+		expected, err := a.Digest(fs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error calculating digest of asset %v", a.Name)
+		}
+
+		actual, err := r.remoteDigest(cli, httpCli, remote.GetID())
+		if err != nil {
+			return nil, errors.Wrapf(err, "error downloading release asset %v", a.Name)
+		}
+
+		if actual != expected {
+			log.WithField("asset", a.Name).Errorf("digest mismatch: expected sha256:%v, got sha256:%v", expected, actual)
+			failed = append(failed, verificationFailure{Asset: a, Reason: "digest mismatch"})
+			continue
+		}
+
+		log.WithField("asset", a.Name).Debug("asset verified")
+	}
+
+	return failed, nil
+}
+
+// ListAssets returns all release assets of a published release
+func (r *Release) ListAssets(cli RepositoriesClient) ([]github.ReleaseAsset, error) {
+	assets := make([]github.ReleaseAsset, 0)
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		page, res, err := cli.ListReleaseAssets(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			r.ID,
+			opt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing release assets")
+		}
+
+		for _, a := range page {
+			assets = append(assets, *a)
+		}
+
+		if res == nil || res.NextPage == 0 {
+			break
+		}
+		opt.Page = res.NextPage
+	}
+
+	return assets, nil
+}
+
+// releaseAssetDigest is a content digest of a release asset, that is not supported by the client library
+type releaseAssetDigest struct {
+	Name   string `json:"name"`
+	Digest string `json:"digest"`
+}
+
+// assetDigests returns SHA-256 digests of release assets by name as reported by the API.
+// Assets uploaded before digests were introduced (or to GitHub Enterprise Server without them) are omitted.
+func (r *Release) assetDigests(api APIClient, id int64) (map[string]string, error) {
+	digests := make(map[string]string)
+
+	page := 1
+	for {
+		req, err := api.NewRequest(
+			"GET",
+			fmt.Sprintf("repos/%v/%v/releases/%v/assets?per_page=100&page=%v", r.Slug.Owner, r.Slug.Name, id, page),
+			nil,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating a request")
+		}
+
+		assets := make([]releaseAssetDigest, 0)
+		res, err := api.Do(context.Background(), req, &assets)
+		if err != nil {
+			return nil, errors.Wrap(err, "error retrieving release assets digests")
+		}
+
+		for _, a := range assets {
+			if strings.HasPrefix(a.Digest, "sha256:") {
+				digests[a.Name] = strings.TrimPrefix(a.Digest, "sha256:")
+			}
+		}
+
+		if res == nil || res.NextPage == 0 {
+			break
+		}
+		page = res.NextPage
+	}
+
+	return digests, nil
+}
+
+// remoteDigest streams a release asset through SHA-256
+func (r *Release) remoteDigest(cli RepositoriesClient, httpCli *http.Client, id int64) (string, error) {
+	rc, redirect, err := cli.DownloadReleaseAsset(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		id,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if redirect != "" {
+		res, err := httpCli.Get(redirect)
+		if err != nil {
+			return "", err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			_ = res.Body.Close()
+			return "", errors.New(fmt.Sprintf("unexpected response status %v", res.Status))
+		}
+
+		rc = res.Body
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
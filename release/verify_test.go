@@ -0,0 +1,200 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"git-release/mocks"
+
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVerifyAssets(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+	fs := afero.NewOsFs()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	type downloadMock struct {
+		Content  string
+		Redirect string
+		Error    error
+	}
+
+	type test struct {
+		Reupload        bool
+		ListAssetsMock  [][]*github.ReleaseAsset
+		DownloadMock    []downloadMock
+		DeleteAssetMock bool
+		UploadAssetMock bool
+		ExpectedError   string
+	}
+
+	uploaded := []*github.ReleaseAsset{
+		{
+			ID:   int64P(2),
+			Name: stringP("test-File1"),
+		},
+	}
+
+	suite := map[string]test{
+		"Verified": {
+			ListAssetsMock: [][]*github.ReleaseAsset{uploaded},
+			DownloadMock: []downloadMock{
+				{
+					Content: "content",
+				},
+			},
+			ExpectedError: "",
+		},
+		"Verified via Redirect": {
+			ListAssetsMock: [][]*github.ReleaseAsset{uploaded},
+			DownloadMock: []downloadMock{
+				{
+					Redirect: server.URL,
+				},
+			},
+			ExpectedError: "",
+		},
+		"Digest Mismatch": {
+			ListAssetsMock: [][]*github.ReleaseAsset{uploaded},
+			DownloadMock: []downloadMock{
+				{
+					Content: "conte",
+				},
+			},
+			ExpectedError: "assets verification failed: test/File1 (digest mismatch)",
+		},
+		"Missing Asset": {
+			ListAssetsMock: [][]*github.ReleaseAsset{{}},
+			ExpectedError:  "assets verification failed: test/File1 (missing)",
+		},
+		"Download Error": {
+			ListAssetsMock: [][]*github.ReleaseAsset{uploaded},
+			DownloadMock: []downloadMock{
+				{
+					Error: errors.New("reason"),
+				},
+			},
+			ExpectedError: "error downloading release asset test/File1: reason",
+		},
+		"Reupload": {
+			Reupload:       true,
+			ListAssetsMock: [][]*github.ReleaseAsset{uploaded, uploaded, uploaded},
+			DownloadMock: []downloadMock{
+				{
+					Content: "conte",
+				},
+				{
+					Content: "content",
+				},
+			},
+			DeleteAssetMock: true,
+			UploadAssetMock: true,
+			ExpectedError:   "",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		// prepare test case
+		if err := afero.WriteFile(fs, "testFile1", []byte("content"), 0644); err != nil {
+			t.Errorf("error preparing test case: error creating file testFile1: %v", err)
+			continue
+		}
+		time.Sleep(30 * time.Millisecond)
+
+		rel := &release.Release{
+			ID: 1,
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				Tag: "1.0.0",
+			},
+			Assets: &[]release.Asset{
+				{
+					Name: "test/File1",
+					Path: "testFile1",
+				},
+			},
+		}
+
+		// test
+		m := new(mocks.RepositoriesClient)
+
+		for _, l := range test.ListAssetsMock {
+			m.On("ListReleaseAssets",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				rel.ID,
+				&github.ListOptions{PerPage: 100}).Return(l, &github.Response{}, nil).Once()
+		}
+
+		for _, d := range test.DownloadMock {
+			var rc io.ReadCloser
+			if d.Content != "" {
+				rc = io.NopCloser(strings.NewReader(d.Content))
+			}
+
+			m.On("DownloadReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(2)).Return(rc, d.Redirect, d.Error).Once()
+		}
+
+		if test.DeleteAssetMock {
+			m.On("DeleteReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				int64(2)).Return(nil, nil).Once()
+		}
+
+		if test.UploadAssetMock {
+			m.On("UploadReleaseAsset",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				rel.ID,
+				&github.UploadOptions{
+					Name: "test-File1",
+				},
+				mock.AnythingOfType("*os.File")).Return(nil, nil, nil).Once()
+		}
+
+		err := rel.VerifyAssets(fs, m, server.Client(), test.Reupload)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+
+		// cleanup
+		if err := fs.Remove("testFile1"); err != nil {
+			t.Errorf("error cleanup: error removing file testFile1: %v", err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+}
@@ -0,0 +1,88 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// VersionChangelog is a VERSION value resolving the version from the top changelog entry
+const VersionChangelog string = "changelog"
+
+// CreateTag creates a release tag pointing to the release commit, annotated with a 'message' unless it is empty.
+// An existing tag is reused as long as it points to the release commit.
+func (r *Release) CreateTag(cli GitClient, message string) error {
+	ref, _, err := cli.GetRef(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		fmt.Sprintf("refs/tags/%v", r.Reference.Tag),
+	)
+	if err == nil {
+		sha := ref.GetObject().GetSHA()
+		if ref.GetObject().GetType() == "tag" {
+			tag, _, err := cli.GetTag(
+				context.Background(),
+				r.Slug.Owner,
+				r.Slug.Name,
+				sha,
+			)
+			if err != nil {
+				return errors.Wrapf(err, "error retrieving %v tag object", r.Reference.Tag)
+			}
+			sha = tag.GetObject().GetSHA()
+		}
+
+		if sha != r.Reference.CommitHash {
+			return errors.New(fmt.Sprintf("tag %v already exists and points at %v instead of GITHUB_SHA %v", r.Reference.Tag, sha, r.Reference.CommitHash))
+		}
+
+		log.Infof("tag %v already exists", r.Reference.Tag)
+		return nil
+	} else if !strings.Contains(err.Error(), "404 Not Found") {
+		return errors.Wrapf(err, "error retrieving %v tag", r.Reference.Tag)
+	}
+
+	sha := r.Reference.CommitHash
+	if message != "" {
+		tag, _, err := cli.CreateTag(
+			context.Background(),
+			r.Slug.Owner,
+			r.Slug.Name,
+			&github.Tag{
+				Tag:     github.String(r.Reference.Tag),
+				Message: github.String(message),
+				Object: &github.GitObject{
+					Type: github.String("commit"),
+					SHA:  github.String(r.Reference.CommitHash),
+				},
+			},
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error creating %v tag object", r.Reference.Tag)
+		}
+		sha = tag.GetSHA()
+	}
+
+	_, _, err = cli.CreateRef(
+		context.Background(),
+		r.Slug.Owner,
+		r.Slug.Name,
+		&github.Reference{
+			Ref: github.String(fmt.Sprintf("refs/tags/%v", r.Reference.Tag)),
+			Object: &github.GitObject{
+				SHA: github.String(sha),
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error creating %v tag", r.Reference.Tag)
+	}
+
+	log.Infof("tag %v created 🎉", r.Reference.Tag)
+	return nil
+}
@@ -0,0 +1,144 @@
+package release_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"git-release/mocks"
+	"git-release/release"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTag(t *testing.T) {
+	a := assert.New(t)
+	log.SetOutput(io.Discard)
+
+	notFound := errors.New("GET https://api.github.com/repos/anton-yurchenko/git-release/git/refs/tags/v1.0.0: 404 Not Found []")
+
+	type test struct {
+		Message        string
+		Existing       *github.Reference
+		GetRefError    error
+		ExistingTag    *github.Tag
+		CreateTagError error
+		CreateRefError error
+		ExpectedTag    bool
+		ExpectedRef    string
+		ExpectedError  string
+	}
+
+	suite := map[string]test{
+		"Lightweight Tag": {
+			GetRefError: notFound,
+			ExpectedRef: "111",
+		},
+		"Annotated Tag": {
+			Message:     "Release v1.0.0",
+			GetRefError: notFound,
+			ExpectedTag: true,
+			ExpectedRef: "222",
+		},
+		"Existing Tag": {
+			Existing: &github.Reference{Object: &github.GitObject{Type: stringP("commit"), SHA: stringP("111")}},
+		},
+		"Existing Annotated Tag": {
+			Existing:    &github.Reference{Object: &github.GitObject{Type: stringP("tag"), SHA: stringP("222")}},
+			ExistingTag: &github.Tag{Object: &github.GitObject{SHA: stringP("111")}},
+		},
+		"Existing Tag of another Commit": {
+			Existing:      &github.Reference{Object: &github.GitObject{Type: stringP("commit"), SHA: stringP("000")}},
+			ExpectedError: "tag v1.0.0 already exists and points at 000 instead of GITHUB_SHA 111",
+		},
+		"GetRef Error": {
+			GetRefError:   errors.New("reason"),
+			ExpectedError: "error retrieving v1.0.0 tag: reason",
+		},
+		"CreateTag Error": {
+			Message:        "Release v1.0.0",
+			GetRefError:    notFound,
+			CreateTagError: errors.New("reason"),
+			ExpectedTag:    true,
+			ExpectedError:  "error creating v1.0.0 tag object: reason",
+		},
+		"CreateRef Error": {
+			GetRefError:    notFound,
+			CreateRefError: errors.New("reason"),
+			ExpectedRef:    "111",
+			ExpectedError:  "error creating v1.0.0 tag: reason",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		rel := &release.Release{
+			Slug: &release.Slug{
+				Owner: "anton-yurchenko",
+				Name:  "git-release",
+			},
+			Reference: &release.Reference{
+				CommitHash: "111",
+				Tag:        "v1.0.0",
+				Version:    "1.0.0",
+			},
+		}
+
+		// test
+		m := new(mocks.GitClient)
+
+		m.On("GetRef",
+			context.Background(),
+			rel.Slug.Owner,
+			rel.Slug.Name,
+			"refs/tags/v1.0.0").Return(test.Existing, nil, test.GetRefError).Once()
+
+		if test.ExistingTag != nil {
+			m.On("GetTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				"222").Return(test.ExistingTag, nil, nil).Once()
+		}
+
+		if test.ExpectedTag {
+			m.On("CreateTag",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.Tag{
+					Tag:     stringP("v1.0.0"),
+					Message: stringP(test.Message),
+					Object: &github.GitObject{
+						Type: stringP("commit"),
+						SHA:  stringP("111"),
+					},
+				}).Return(&github.Tag{SHA: stringP("222")}, nil, test.CreateTagError).Once()
+		}
+
+		if test.ExpectedRef != "" {
+			m.On("CreateRef",
+				context.Background(),
+				rel.Slug.Owner,
+				rel.Slug.Name,
+				&github.Reference{
+					Ref: stringP("refs/tags/v1.0.0"),
+					Object: &github.GitObject{
+						SHA: stringP(test.ExpectedRef),
+					},
+				}).Return(nil, nil, test.CreateRefError).Once()
+		}
+
+		err := rel.CreateTag(m, test.Message)
+		if test.ExpectedError != "" || err != nil {
+			a.EqualError(err, test.ExpectedError)
+		}
+		m.AssertExpectations(t)
+	}
+}
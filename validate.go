@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"git-release/release"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// validate reports changelog problems as GitHub Actions annotations
+func validate(fs afero.Fs, conf *Configuration) error {
+	if conf.ChangelogFile == "" {
+		return errors.New("changelog file not found")
+	}
+
+	problems, err := release.ValidateChangelog(fs, conf.ChangelogFile)
+	if err != nil {
+		return errors.Wrap(err, "error validating changelog")
+	}
+
+	file, err := filepath.Rel(os.Getenv("GITHUB_WORKSPACE"), conf.ChangelogFile)
+	if err != nil {
+		file = conf.ChangelogFile
+	}
+
+	var failed int
+	for _, p := range problems {
+		fmt.Println(p.Annotation(file))
+
+		if p.Severity == release.SeverityError {
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return errors.New(fmt.Sprintf("changelog validation failed: %v error(s) found", failed))
+	}
+
+	log.Info("changelog is valid 🎉")
+
+	return nil
+}